@@ -0,0 +1,167 @@
+// Package bravozerotest provides assertion and golden-file helpers for testing
+// code built on the Bravo Zero SDK.
+package bravozerotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DeepCreative/bravozero-go/bravozero"
+)
+
+// UpdateEnv is the environment variable that, when set to "1", makes
+// AssertGolden rewrite golden files instead of comparing against them.
+const UpdateEnv = "BRAVOZERO_UPDATE_GOLDEN"
+
+// Placeholders substituted for volatile values by NormalizeJSON.
+const (
+	IDPlaceholder        = "<id>"
+	TimestampPlaceholder = "<timestamp>"
+)
+
+// AssertMemoryEqual fails the test if the two memories differ in anything
+// other than server-assigned IDs, versions and timestamps, or whether they
+// were served from a cache.
+func AssertMemoryEqual(t testing.TB, want, got *bravozero.Memory) {
+	t.Helper()
+	if want == nil || got == nil {
+		if want != got {
+			t.Fatalf("memory mismatch: want %v, got %v", want, got)
+		}
+		return
+	}
+	w, g := normalizeMemory(*want), normalizeMemory(*got)
+	if !reflect.DeepEqual(w, g) {
+		t.Fatalf("memory mismatch:\nwant: %s\ngot:  %s", mustJSON(t, w), mustJSON(t, g))
+	}
+}
+
+func normalizeMemory(m bravozero.Memory) bravozero.Memory {
+	m.ID = ""
+	m.CreatedAt = time.Time{}
+	m.LastAccessedAt = time.Time{}
+	m.ExpiresAt = time.Time{}
+	m.Version = ""
+	m.Stale = false
+	m.CachedAt = time.Time{}
+	return m
+}
+
+// AssertEvaluation fails the test if the result's decision differs from want
+// or if any of the given rule IDs did not match during evaluation.
+func AssertEvaluation(t testing.TB, got *bravozero.EvaluationResult, want bravozero.Decision, matchedRuleIDs ...string) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("evaluation result is nil, want decision %q", want)
+		return
+	}
+	if got.Decision != want {
+		t.Fatalf("decision = %q, want %q (reasoning: %s)", got.Decision, want, got.Reasoning)
+	}
+	matched := make(map[string]bool, len(got.AppliedRules))
+	for _, r := range got.AppliedRules {
+		if r.Matched {
+			matched[r.RuleID] = true
+		}
+	}
+	for _, id := range matchedRuleIDs {
+		if !matched[id] {
+			t.Errorf("rule %q did not match", id)
+		}
+	}
+}
+
+// AssertGolden marshals v to indented JSON, normalizes IDs and timestamps, and
+// compares the result with testdata/<name>.golden. Set UpdateEnv=1 to rewrite
+// the golden file.
+func AssertGolden(t testing.TB, name string, v interface{}) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", name, err)
+	}
+	got, err := NormalizeJSON(data)
+	if err != nil {
+		t.Fatalf("failed to normalize %s: %v", name, err)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(UpdateEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with %s=1 to create): %v", UpdateEnv, err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(want), bytes.TrimSpace(got)) {
+		t.Fatalf("%s does not match golden file:\nwant: %s\ngot:  %s", name, want, got)
+	}
+}
+
+// NormalizeJSON replaces ID fields and RFC 3339 timestamps with placeholders
+// and returns the result as indented JSON with sorted keys.
+func NormalizeJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(normalizeValue("", v), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func normalizeValue(key string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			val[k] = normalizeValue(k, child)
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = normalizeValue(key, child)
+		}
+		return val
+	case string:
+		if val == "" {
+			return val
+		}
+		if isIDKey(key) {
+			return IDPlaceholder
+		}
+		if _, err := time.Parse(time.RFC3339Nano, val); err == nil {
+			return TimestampPlaceholder
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+func isIDKey(key string) bool {
+	return key == "id" || strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "ID")
+}
+
+func mustJSON(t testing.TB, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	return data
+}
@@ -3,11 +3,16 @@ package bravozero
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	pathpkg "path"
+	"strings"
 	"time"
 )
 
@@ -20,6 +25,18 @@ type FileInfo struct {
 	ModifiedAt  time.Time `json:"modifiedAt"`
 	CreatedAt   time.Time `json:"createdAt,omitempty"`
 	Permissions string    `json:"permissions"`
+	// ContentType is the MIME type given with WithContentType when the file
+	// was written, or detected by the server from its name and content.
+	ContentType string `json:"contentType,omitempty"`
+	// Attributes holds custom VFS attributes set with SetAttributes.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Metadata holds the key-value annotations set with SetFileMetadata.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Checksum is the hex SHA-256 of the stored content. For files under an
+	// encrypted prefix it is the checksum of the ciphertext.
+	Checksum string `json:"checksum,omitempty"`
+	// Lock is set if an agent holds an advisory lock on the file.
+	Lock *FileLock `json:"lock,omitempty"`
 }
 
 // DirectoryListing represents a listing of files in a directory.
@@ -35,6 +52,45 @@ type SyncStatus struct {
 	Synced         bool      `json:"synced"`
 	LastSyncAt     time.Time `json:"lastSyncAt,omitempty"`
 	PendingChanges int       `json:"pendingChanges"`
+	// Conflicts is the number of pending changes blocked by conflicts; see
+	// ListConflicts.
+	Conflicts int `json:"conflicts"`
+}
+
+// rawFileInfo is the wire representation of FileInfo.
+type rawFileInfo struct {
+	Path        string            `json:"path"`
+	Name        string            `json:"name"`
+	Size        int64             `json:"size"`
+	IsDirectory bool              `json:"isDirectory"`
+	ModifiedAt  string            `json:"modifiedAt"`
+	CreatedAt   string            `json:"createdAt"`
+	Permissions string            `json:"permissions"`
+	ContentType string            `json:"contentType"`
+	Attributes  map[string]string `json:"attributes"`
+	Metadata    map[string]string `json:"metadata"`
+	Checksum    string            `json:"checksum"`
+	Lock        *rawFileLock      `json:"lock"`
+}
+
+func (f rawFileInfo) toFileInfo() *FileInfo {
+	modifiedAt, _ := time.Parse(time.RFC3339, f.ModifiedAt)
+	createdAt, _ := time.Parse(time.RFC3339, f.CreatedAt)
+
+	return &FileInfo{
+		Path:        f.Path,
+		Name:        f.Name,
+		Size:        f.Size,
+		IsDirectory: f.IsDirectory,
+		ModifiedAt:  modifiedAt,
+		CreatedAt:   createdAt,
+		Permissions: f.Permissions,
+		ContentType: f.ContentType,
+		Attributes:  f.Attributes,
+		Metadata:    f.Metadata,
+		Checksum:    f.Checksum,
+		Lock:        f.Lock.toFileLock(),
+	}
 }
 
 // BridgeClient provides access to the Forge Bridge API.
@@ -44,6 +100,21 @@ type BridgeClient struct {
 	agentID       string
 	authenticator *PersonaAuthenticator
 	httpClient    *http.Client
+	encryption    *bridgeEncryption
+	fileCache     *FileCache
+	guard         *Guard
+	// throttled is set by WithBandwidthLimit. Throttled transfers can run
+	// for longer than any fixed timeout, so requests then rely on ctx alone.
+	throttled bool
+}
+
+// BridgeOption is a function that configures a BridgeClient
+type BridgeOption func(*BridgeClient)
+
+func withBridgeTransport(rt http.RoundTripper) BridgeOption {
+	return func(c *BridgeClient) {
+		c.httpClient.Transport = rt
+	}
 }
 
 // NewBridgeClient creates a new Forge Bridge client.
@@ -51,8 +122,9 @@ func NewBridgeClient(
 	baseURL, apiKey, agentID string,
 	auth *PersonaAuthenticator,
 	timeoutSeconds int,
+	opts ...BridgeOption,
 ) *BridgeClient {
-	return &BridgeClient{
+	c := &BridgeClient{
 		baseURL:       baseURL + "/v1/bridge",
 		apiKey:        apiKey,
 		agentID:       agentID,
@@ -61,9 +133,17 @@ func NewBridgeClient(
 			Timeout: time.Duration(timeoutSeconds) * time.Second,
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *BridgeClient) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.doRequestWithHeaders(ctx, method, path, body, nil)
+}
+
+func (c *BridgeClient) doRequestWithHeaders(ctx context.Context, method, path string, body interface{}, headers map[string]string) (*http.Response, error) {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -77,11 +157,14 @@ func (c *BridgeClient) doRequest(ctx context.Context, method, path string, body
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if fn := uploadProgressFromContext(ctx); fn != nil && req.Body != nil {
+		req.Body = &progressReadCloser{ReadCloser: req.Body, fn: fn, total: req.ContentLength}
+	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-API-Key", c.apiKey)
 	req.Header.Set("X-Agent-ID", c.agentID)
-	req.Header.Set("User-Agent", "bravozero-go/1.0.0")
+	req.Header.Set("User-Agent", "bravozero-go/"+Version)
 
 	if c.authenticator != nil {
 		attestation, err := c.authenticator.CreateAttestation("")
@@ -91,7 +174,19 @@ func (c *BridgeClient) doRequest(ctx context.Context, method, path string, body
 		req.Header.Set("X-Persona-Attestation", attestation)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	if token := executionTokenFromContext(ctx); token != "" {
+		req.Header.Set("X-Execution-Token", token)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := c.httpClient
+	if c.throttled {
+		client = c.streamClient()
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -101,19 +196,64 @@ func (c *BridgeClient) doRequest(ctx context.Context, method, path string, body
 		return nil, &RateLimitError{RetryAfter: 60}
 	}
 
+	if resp.StatusCode == 412 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, parsePreconditionFailedError(body)
+	}
+
+	if resp.StatusCode == 423 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, parseFileLockedError(body)
+	}
+
+	if resp.StatusCode == 404 {
+		resp.Body.Close()
+		return nil, c.notFound(ctx, req)
+	}
+
+	if resp.StatusCode == 403 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if denied := parseConstitutionDenied(body); denied != nil {
+			return nil, denied
+		}
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return resp, nil
 }
 
+// fileInfo converts a wire FileInfo, mapping encrypted paths back to the
+// caller-visible form.
+func (c *BridgeClient) fileInfo(ctx context.Context, raw rawFileInfo) *FileInfo {
+	info := raw.toFileInfo()
+	info.Path = c.localPath(ctx, raw.Path)
+	if info.Path != raw.Path {
+		info.Name = pathpkg.Base(info.Path)
+	}
+	if info.Lock != nil {
+		info.Lock.Path = info.Path
+	}
+	return info
+}
+
 // ListFiles lists files in a directory.
 func (c *BridgeClient) ListFiles(ctx context.Context, path string, recursive bool, pattern string) (*DirectoryListing, error) {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
 	params := url.Values{}
-	params.Set("path", path)
+	params.Set("path", remote)
 	if recursive {
 		params.Set("recursive", "true")
 	}
@@ -128,17 +268,9 @@ func (c *BridgeClient) ListFiles(ctx context.Context, path string, recursive boo
 	defer resp.Body.Close()
 
 	var data struct {
-		Path       string `json:"path"`
-		Files      []struct {
-			Path        string `json:"path"`
-			Name        string `json:"name"`
-			Size        int64  `json:"size"`
-			IsDirectory bool   `json:"isDirectory"`
-			ModifiedAt  string `json:"modifiedAt"`
-			CreatedAt   string `json:"createdAt"`
-			Permissions string `json:"permissions"`
-		} `json:"files"`
-		TotalCount int `json:"totalCount"`
+		Path       string        `json:"path"`
+		Files      []rawFileInfo `json:"files"`
+		TotalCount int           `json:"totalCount"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
@@ -147,21 +279,11 @@ func (c *BridgeClient) ListFiles(ctx context.Context, path string, recursive boo
 
 	files := make([]FileInfo, len(data.Files))
 	for i, f := range data.Files {
-		modifiedAt, _ := time.Parse(time.RFC3339, f.ModifiedAt)
-		createdAt, _ := time.Parse(time.RFC3339, f.CreatedAt)
-		files[i] = FileInfo{
-			Path:        f.Path,
-			Name:        f.Name,
-			Size:        f.Size,
-			IsDirectory: f.IsDirectory,
-			ModifiedAt:  modifiedAt,
-			CreatedAt:   createdAt,
-			Permissions: f.Permissions,
-		}
+		files[i] = *c.fileInfo(ctx, f)
 	}
 
 	return &DirectoryListing{
-		Path:       data.Path,
+		Path:       c.localPath(ctx, data.Path),
 		Files:      files,
 		TotalCount: data.TotalCount,
 	}, nil
@@ -169,8 +291,18 @@ func (c *BridgeClient) ListFiles(ctx context.Context, path string, recursive boo
 
 // ReadFile reads a file's contents.
 func (c *BridgeClient) ReadFile(ctx context.Context, path string) (string, error) {
+	if c.fileCache != nil {
+		data, err := c.ReadFileBytes(ctx, path)
+		return string(data), err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
 	params := url.Values{}
-	params.Set("path", path)
+	params.Set("path", remote)
 
 	resp, err := c.doRequest(ctx, "GET", "/file?"+params.Encode(), nil)
 	if err != nil {
@@ -186,22 +318,202 @@ func (c *BridgeClient) ReadFile(ctx context.Context, path string) (string, error
 		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return data.Content, nil
+	content, err := c.decryptContent(ctx, path, []byte(data.Content))
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
 }
 
 // ReadFileBytes reads a file as bytes.
-func (c *BridgeClient) ReadFileBytes(ctx context.Context, path string) ([]byte, error) {
+func (c *BridgeClient) ReadFileBytes(ctx context.Context, path string, opts ...TransferOption) ([]byte, error) {
+	rc, err := c.Open(ctx, path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// Open opens a file for incremental reading. The caller must close the
+// returned reader. Unlike ReadFileBytes, the content is not buffered in
+// memory, except for files under an encrypted prefix, which must be read
+// in full to be decrypted. The download is not subject to the client's
+// request timeout; cancel ctx to abandon it.
+//
+// The reader implements io.WriterTo, so io.Copy to a file copies in large
+// chunks.
+//
+// With WithVerifyChecksum, the final Read returns an *IntegrityError instead
+// of io.EOF if the content does not match the server's checksum.
+func (c *BridgeClient) Open(ctx context.Context, path string, opts ...TransferOption) (io.ReadCloser, error) {
+	cfg := transferConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+
+	body, err := c.openCached(ctx, path, remote, "/file/bytes?"+params.Encode(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.encryption.encryptedPrefix(path); !ok {
+		return &fileReader{body}, nil
+	}
+
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	content, err := c.decryptContent(ctx, path, data)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// openCached streams a file, revalidating any copy in the file cache with
+// If-None-Match and caching the response if it carries an ETag.
+func (c *BridgeClient) openCached(ctx context.Context, path, remote, reqPath string, cfg transferConfig) (io.ReadCloser, error) {
+	progress := cfg.progress
+	headers := map[string]string{"Accept": "application/octet-stream"}
+	if cfg.verify {
+		headers["TE"] = "trailers"
+	}
+	etag, cached := c.fileCache.etag(remote)
+	if cached {
+		headers["If-None-Match"] = etag
+	}
+
+	resp, err := c.doStream(ctx, reqPath, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		if data, ok := c.fileCache.get(remote, etag); ok {
+			if progress != nil {
+				progress(int64(len(data)), int64(len(data)))
+			}
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+		// Evicted since the request was made; fetch unconditionally.
+		delete(headers, "If-None-Match")
+		resp, err = c.doStream(ctx, reqPath, headers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body := resp.Body
+	if cfg.verify {
+		body = &verifyingReader{ReadCloser: body, resp: resp, path: path, hash: sha256.New()}
+	}
+	if progress != nil {
+		body = &progressReadCloser{ReadCloser: body, fn: progress, total: resp.ContentLength}
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" && c.fileCache != nil {
+		return &cachingReader{ReadCloser: body, cache: c.fileCache, path: remote, etag: etag}, nil
+	}
+	return body, nil
+}
+
+// ReadFileRange reads length bytes of a file starting at offset using an HTTP
+// Range request. A length of 0 or less reads to the end of the file, and a
+// negative offset reads the last -offset bytes, which is useful for tailing
+// logs. Fewer bytes than requested are returned if the file is shorter, and
+// none if offset is at or past its end. Files under an encrypted prefix
+// cannot be read by range.
+func (c *BridgeClient) ReadFileRange(ctx context.Context, path string, offset, length int64) ([]byte, error) {
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return nil, fmt.Errorf("cannot read a range of encrypted path %s", path)
+	}
+
+	var rangeHeader string
+	switch {
+	case offset < 0:
+		rangeHeader = fmt.Sprintf("bytes=%d", offset)
+	case length > 0:
+		rangeHeader = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	default:
+		rangeHeader = fmt.Sprintf("bytes=%d-", offset)
+	}
+
 	params := url.Values{}
 	params.Set("path", path)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/file/bytes?"+params.Encode(), nil)
+	resp, err := c.doStream(ctx, "/file/bytes?"+params.Encode(), map[string]string{
+		"Accept": "application/octet-stream",
+		"Range":  rangeHeader,
+	})
+	if err != nil {
+		// 416 Range Not Satisfiable: offset is at or past the end of the file.
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			return []byte{}, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPartialContent {
+		return io.ReadAll(resp.Body)
+	}
+
+	// The server ignored the Range header and sent the whole file.
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return sliceRange(data, offset, length), nil
+}
+
+func sliceRange(data []byte, offset, length int64) []byte {
+	size := int64(len(data))
+	if offset < 0 {
+		offset = max(size+offset, 0)
+		length = 0
+	}
+	if offset >= size {
+		return []byte{}
+	}
+	end := size
+	if length > 0 && offset+length < size {
+		end = offset + length
+	}
+	return data[offset:end]
+}
+
+// streamClient returns an HTTP client sharing c's transport but without its
+// per-request timeout, which would cut off a body still being streamed after
+// the timeout elapses. Requests made with it are bounded by their ctx only.
+func (c *BridgeClient) streamClient() *http.Client {
+	return &http.Client{Transport: c.httpClient.Transport}
+}
+
+// doStream performs a GET whose response body is returned unread for the
+// caller to stream. It is not subject to the client's request timeout.
+func (c *BridgeClient) doStream(ctx context.Context, path string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Accept", "application/octet-stream")
 	req.Header.Set("X-API-Key", c.apiKey)
 	req.Header.Set("X-Agent-ID", c.agentID)
+	req.Header.Set("User-Agent", "bravozero-go/"+Version)
 
 	if c.authenticator != nil {
 		attestation, err := c.authenticator.CreateAttestation("")
@@ -211,62 +523,402 @@ func (c *BridgeClient) ReadFileBytes(ctx context.Context, path string) ([]byte,
 		req.Header.Set("X-Persona-Attestation", attestation)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	if token := executionTokenFromContext(ctx); token != "" {
+		req.Header.Set("X-Execution-Token", token)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.streamClient().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	return io.ReadAll(resp.Body)
+	if resp.StatusCode == 429 {
+		resp.Body.Close()
+		return nil, &RateLimitError{RetryAfter: 60}
+	}
+
+	if resp.StatusCode == 404 {
+		resp.Body.Close()
+		return nil, c.notFound(ctx, req)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return resp, nil
+}
+
+// notFound builds the error for a 404 response, identifying the file by the
+// request's path parameter where there is one.
+func (c *BridgeClient) notFound(ctx context.Context, req *http.Request) error {
+	if p := req.URL.Query().Get("path"); p != "" {
+		return &NotFoundError{Resource: "file", ID: c.localPath(ctx, p)}
+	}
+	return &NotFoundError{Resource: "resource", ID: strings.TrimPrefix(req.URL.Path, "/v1/bridge")}
+}
+
+// TransferOption configures a file upload or download.
+type TransferOption func(*transferConfig)
+
+// WriteOption configures WriteFile.
+type WriteOption = TransferOption
+
+type transferConfig struct {
+	ifMatch     string
+	ifNoneMatch string
+	progress    ProgressFunc
+	contentType string
+	mtime       time.Time
+	verify      bool
+	atomic      bool
+}
+
+// WithIfMatch only writes if the file's current checksum equals checksum,
+// so an edit based on a stale read is rejected instead of clobbering a
+// concurrent change. It has no effect on reads.
+func WithIfMatch(checksum string) WriteOption {
+	return func(c *transferConfig) {
+		c.ifMatch = checksum
+	}
+}
+
+// WithIfNoneMatch only writes if the file's current checksum differs from
+// checksum. Pass "*" to only create the file if it does not exist. It has no
+// effect on reads.
+func WithIfNoneMatch(checksum string) WriteOption {
+	return func(c *transferConfig) {
+		c.ifNoneMatch = checksum
+	}
+}
+
+// WithMtime sets the written file's modification time to t instead of the
+// time of the write, so tools copying files can preserve their original
+// timestamps. It has no effect on reads.
+func WithMtime(t time.Time) WriteOption {
+	return func(c *transferConfig) {
+		c.mtime = t
+	}
+}
+
+// WithContentType sets the written file's MIME type instead of letting the
+// server detect it. Set it for files under an encrypted prefix, where the
+// server only sees ciphertext. It has no effect on reads.
+func WithContentType(contentType string) WriteOption {
+	return func(c *transferConfig) {
+		c.contentType = contentType
+	}
+}
+
+// WithAtomicWrite has the server write the content to a temporary file and
+// rename it over path only once the write has completed, so readers never
+// observe a partially written file. It has no effect on reads.
+func WithAtomicWrite() WriteOption {
+	return func(c *transferConfig) {
+		c.atomic = true
+	}
+}
+
+// WriteFile writes content to a file. If a WithIfMatch or WithIfNoneMatch
+// precondition fails, a *PreconditionFailedError is returned.
+//
+// content is sent as JSON text, so bytes that are not valid UTF-8 are
+// replaced; use WriteFileBytes for binary content.
+func (c *BridgeClient) WriteFile(ctx context.Context, path, content string, createDirs bool, opts ...WriteOption) (*FileInfo, error) {
+	return c.writeFile(ctx, path, []byte(content), false, createDirs, opts...)
 }
 
-// WriteFile writes content to a file.
-func (c *BridgeClient) WriteFile(ctx context.Context, path, content string, createDirs bool) (*FileInfo, error) {
+// WriteFileBytes writes data to a file byte for byte. It is WriteFile for
+// binary content such as images and archives: the data is base64-encoded on
+// the wire so no byte sequence is altered.
+func (c *BridgeClient) WriteFileBytes(ctx context.Context, path string, data []byte, createDirs bool, opts ...WriteOption) (*FileInfo, error) {
+	return c.writeFile(ctx, path, data, true, createDirs, opts...)
+}
+
+func (c *BridgeClient) writeFile(ctx context.Context, path string, content []byte, binary, createDirs bool, opts ...WriteOption) (*FileInfo, error) {
+	cfg := transferConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, err := c.guard.check(ctx, "bridge.write", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := c.encryptContent(ctx, path, content)
+	if err != nil {
+		return nil, err
+	}
+
 	body := map[string]interface{}{
-		"path":       path,
-		"content":    content,
+		"path":       remote,
+		"content":    string(stored),
 		"createDirs": createDirs,
 	}
+	// Encrypted content is already base64 text.
+	if _, encrypted := c.encryption.encryptedPrefix(path); binary && !encrypted {
+		body["content"] = base64.StdEncoding.EncodeToString(stored)
+		body["contentEncoding"] = "base64"
+	}
+	if !cfg.mtime.IsZero() {
+		body["modifiedAt"] = cfg.mtime.UTC().Format(time.RFC3339Nano)
+	}
+	if cfg.contentType != "" {
+		body["contentType"] = cfg.contentType
+	}
+	if cfg.atomic {
+		body["atomic"] = true
+	}
 
-	resp, err := c.doRequest(ctx, "PUT", "/file", body)
+	c.fileCache.remove(remote)
+	resp, err := c.doRequestWithHeaders(withUploadProgress(ctx, cfg.progress), "PUT", "/file", body, cfg.headers())
 	if err != nil {
+		var precondition *PreconditionFailedError
+		if errors.As(err, &precondition) {
+			precondition.Path = path
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var data struct {
-		Path        string `json:"path"`
-		Name        string `json:"name"`
-		Size        int64  `json:"size"`
-		IsDirectory bool   `json:"isDirectory"`
-		ModifiedAt  string `json:"modifiedAt"`
-		CreatedAt   string `json:"createdAt"`
-		Permissions string `json:"permissions"`
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
+
+// WriteFileAtomic writes content to a file so that readers see either the
+// old content or the new content, never a partial write. It is WriteFile
+// with WithAtomicWrite.
+func (c *BridgeClient) WriteFileAtomic(ctx context.Context, path, content string, createDirs bool, opts ...WriteOption) (*FileInfo, error) {
+	return c.WriteFile(ctx, path, content, createDirs, append(opts, WithAtomicWrite())...)
+}
+
+// AppendFile appends content to the end of a file, creating it if it does
+// not exist, without reading or rewriting the existing content. Appends from
+// concurrent agents are applied whole, never interleaved. Files under an
+// encrypted prefix cannot be appended to.
+func (c *BridgeClient) AppendFile(ctx context.Context, path, content string) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.append", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return nil, fmt.Errorf("cannot append to encrypted path %s", path)
+	}
+
+	body := map[string]string{
+		"path":    path,
+		"content": content,
+	}
+
+	c.fileCache.remove(path)
+	resp, err := c.doRequest(ctx, "POST", "/file/append", body)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
+	var data rawFileInfo
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	modifiedAt, _ := time.Parse(time.RFC3339, data.ModifiedAt)
-	createdAt, _ := time.Parse(time.RFC3339, data.CreatedAt)
+	return c.fileInfo(ctx, data), nil
+}
 
-	return &FileInfo{
-		Path:        data.Path,
-		Name:        data.Name,
-		Size:        data.Size,
-		IsDirectory: data.IsDirectory,
-		ModifiedAt:  modifiedAt,
-		CreatedAt:   createdAt,
-		Permissions: data.Permissions,
-	}, nil
+// headers returns the conditional request headers for the configured
+// preconditions.
+func (w transferConfig) headers() map[string]string {
+	headers := make(map[string]string)
+	if w.ifMatch != "" {
+		headers["If-Match"] = quoteETag(w.ifMatch)
+	}
+	if w.ifNoneMatch != "" {
+		headers["If-None-Match"] = quoteETag(w.ifNoneMatch)
+	}
+	return headers
+}
+
+// quoteETag formats a checksum as an HTTP entity tag.
+func quoteETag(checksum string) string {
+	if checksum == "*" || strings.HasPrefix(checksum, `"`) {
+		return checksum
+	}
+	return `"` + checksum + `"`
 }
 
-// DeleteFile deletes a file.
-func (c *BridgeClient) DeleteFile(ctx context.Context, path string) error {
+// Exists reports whether a file or directory exists at path.
+func (c *BridgeClient) Exists(ctx context.Context, path string) (bool, error) {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return false, err
+	}
+
 	params := url.Values{}
-	params.Set("path", path)
+	params.Set("path", remote)
+
+	resp, err := c.doRequest(ctx, "HEAD", "/file?"+params.Encode(), nil)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// DeleteFile deletes a file. With WithSoftDelete the file is moved to the
+// trash instead, from where it can be restored with Restore.
+func (c *BridgeClient) DeleteFile(ctx context.Context, path string, opts ...DeleteOption) error {
+	cfg := deleteConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, err := c.guard.check(ctx, "bridge.delete", map[string]interface{}{"path": path, "soft": cfg.soft})
+	if err != nil {
+		return err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+	if cfg.soft {
+		params.Set("soft", "true")
+	}
+
+	c.fileCache.remove(remote)
+	resp, err := c.doRequest(ctx, "DELETE", "/file?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Touch sets a file's modification time to now, creating an empty file if it
+// does not exist.
+func (c *BridgeClient) Touch(ctx context.Context, path string) (*FileInfo, error) {
+	return c.touch(ctx, path, time.Time{}, true)
+}
+
+// SetMtime sets an existing file's modification time to t.
+func (c *BridgeClient) SetMtime(ctx context.Context, path string, t time.Time) (*FileInfo, error) {
+	return c.touch(ctx, path, t, false)
+}
+
+func (c *BridgeClient) touch(ctx context.Context, path string, t time.Time, create bool) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.touch", map[string]interface{}{"path": path, "create": create})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path":   remote,
+		"create": create,
+	}
+	if !t.IsZero() {
+		body["modifiedAt"] = t.UTC().Format(time.RFC3339Nano)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/file/touch", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
+
+// Mkdir creates a directory. The parent directory must already exist.
+func (c *BridgeClient) Mkdir(ctx context.Context, path string) (*FileInfo, error) {
+	return c.mkdir(ctx, path, false)
+}
+
+// MkdirAll creates a directory along with any missing parents. It is not an
+// error if the directory already exists.
+func (c *BridgeClient) MkdirAll(ctx context.Context, path string) (*FileInfo, error) {
+	return c.mkdir(ctx, path, true)
+}
+
+func (c *BridgeClient) mkdir(ctx context.Context, path string, parents bool) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.mkdir", map[string]interface{}{"path": path, "parents": parents})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path":    remote,
+		"parents": parents,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/directory", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
+
+// RemoveAll deletes path and, if it is a directory, everything below it.
+func (c *BridgeClient) RemoveAll(ctx context.Context, path string) error {
+	ctx, err := c.guard.check(ctx, "bridge.delete", map[string]interface{}{"path": path, "recursive": true})
+	if err != nil {
+		return err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+	params.Set("recursive", "true")
 
+	c.fileCache.removeTree(remote)
 	resp, err := c.doRequest(ctx, "DELETE", "/file?"+params.Encode(), nil)
 	if err != nil {
 		return err
@@ -275,13 +927,61 @@ func (c *BridgeClient) DeleteFile(ctx context.Context, path string) error {
 	return nil
 }
 
+// Chmod sets a file's permissions, in the same form as FileInfo.Permissions
+// (e.g. "rwxr-xr-x").
+func (c *BridgeClient) Chmod(ctx context.Context, path, permissions string) (*FileInfo, error) {
+	return c.patchAttributes(ctx, path, map[string]interface{}{"permissions": permissions})
+}
+
+// SetAttributes sets custom VFS attributes on a file, merging them with any
+// existing attributes. An empty value removes the attribute.
+func (c *BridgeClient) SetAttributes(ctx context.Context, path string, attributes map[string]string) (*FileInfo, error) {
+	return c.patchAttributes(ctx, path, map[string]interface{}{"attributes": attributes})
+}
+
+func (c *BridgeClient) patchAttributes(ctx context.Context, path string, body map[string]interface{}) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.set_attributes", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	body["path"] = remote
+
+	resp, err := c.doRequest(ctx, "PATCH", "/file/attributes", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
+
 // Sync triggers VFS synchronization.
 func (c *BridgeClient) Sync(ctx context.Context, path string) (*SyncStatus, error) {
 	if path == "" {
 		path = "/"
 	}
 
-	body := map[string]string{"path": path}
+	ctx, err := c.guard.check(ctx, "bridge.sync", map[string]interface{}{"paths": []string{path}})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{"path": remote}
 
 	resp, err := c.doRequest(ctx, "POST", "/sync", body)
 	if err != nil {
@@ -289,23 +989,150 @@ func (c *BridgeClient) Sync(ctx context.Context, path string) (*SyncStatus, erro
 	}
 	defer resp.Body.Close()
 
+	return c.parseSyncStatus(ctx, resp.Body)
+}
+
+// SyncPaths triggers synchronization of several subtrees in one request and
+// returns their statuses in the same order as paths. As with Sync, an empty
+// path means the root.
+func (c *BridgeClient) SyncPaths(ctx context.Context, paths []string) ([]SyncStatus, error) {
+	normalized := make([]string, len(paths))
+	for i, p := range paths {
+		if p == "" {
+			p = "/"
+		}
+		normalized[i] = p
+	}
+
+	ctx, err := c.guard.check(ctx, "bridge.sync", map[string]interface{}{"paths": normalized})
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]string, len(paths))
+	for i, p := range normalized {
+		remote, err := c.remotePath(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		remotes[i] = remote
+	}
+
+	body := map[string][]string{"paths": remotes}
+
+	resp, err := c.doRequest(ctx, "POST", "/sync/batch", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Statuses []json.RawMessage `json:"statuses"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(data.Statuses) != len(paths) {
+		return nil, fmt.Errorf("sync returned %d statuses for %d paths", len(data.Statuses), len(paths))
+	}
+
+	statuses := make([]SyncStatus, len(data.Statuses))
+	for i, raw := range data.Statuses {
+		status, err := c.parseSyncStatus(ctx, bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		statuses[i] = *status
+	}
+
+	return statuses, nil
+}
+
+// GetSyncStatus returns the synchronization status of path without
+// triggering a sync.
+func (c *BridgeClient) GetSyncStatus(ctx context.Context, path string) (*SyncStatus, error) {
+	if path == "" {
+		path = "/"
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+
+	resp, err := c.doRequest(ctx, "GET", "/sync?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return c.parseSyncStatus(ctx, resp.Body)
+}
+
+// WaitForSync triggers a sync of path and polls until it reports Synced or
+// ctx is done, returning the last status seen. Polling starts at
+// pollInterval and backs off exponentially up to 30s.
+func (c *BridgeClient) WaitForSync(ctx context.Context, path string, pollInterval time.Duration) (*SyncStatus, error) {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	maxInterval := 30 * time.Second
+	if pollInterval > maxInterval {
+		maxInterval = pollInterval
+	}
+
+	status, err := c.Sync(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := pollInterval
+	for !status.Synced {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return status, ctx.Err()
+		}
+
+		status, err = c.GetSyncStatus(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+
+	return status, nil
+}
+
+func (c *BridgeClient) parseSyncStatus(ctx context.Context, r io.Reader) (*SyncStatus, error) {
 	var data struct {
 		Path           string `json:"path"`
 		Synced         bool   `json:"synced"`
 		LastSyncAt     string `json:"lastSyncAt"`
 		PendingChanges int    `json:"pendingChanges"`
+		Conflicts      int    `json:"conflicts"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	lastSync, _ := time.Parse(time.RFC3339, data.LastSyncAt)
 
 	return &SyncStatus{
-		Path:           data.Path,
+		Path:           c.localPath(ctx, data.Path),
 		Synced:         data.Synced,
 		LastSyncAt:     lastSync,
 		PendingChanges: data.PendingChanges,
+		Conflicts:      data.Conflicts,
 	}, nil
 }
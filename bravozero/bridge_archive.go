@@ -0,0 +1,68 @@
+package bravozero
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// ArchiveFormat is the container format produced by DownloadArchive.
+type ArchiveFormat string
+
+const (
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	ArchiveZip   ArchiveFormat = "zip"
+)
+
+// DownloadArchive streams an archive of the VFS subtree at path to w and
+// returns the number of bytes written. The archive is generated server-side
+// in a single request, so files under an encrypted prefix are included as
+// stored, encrypted and with encrypted names if WithEncryptedFilenames is set.
+//
+// Whole-workspace archives can take far longer than the client's request
+// timeout, so the download is bounded only by ctx.
+func (c *BridgeClient) DownloadArchive(ctx context.Context, path string, format ArchiveFormat, w io.Writer, opts ...TransferOption) (int64, error) {
+	cfg := transferConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if format == "" {
+		format = ArchiveTarGz
+	}
+	if format != ArchiveTarGz && format != ArchiveZip {
+		return 0, fmt.Errorf("unsupported archive format %q", format)
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+	params.Set("format", string(format))
+
+	accept := "application/gzip"
+	if format == ArchiveZip {
+		accept = "application/zip"
+	}
+
+	resp, err := c.doStream(ctx, "/archive?"+params.Encode(), map[string]string{"Accept": accept})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if cfg.progress != nil {
+		body = &progressReadCloser{ReadCloser: resp.Body, fn: cfg.progress, total: resp.ContentLength}
+	}
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download archive: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,124 @@
+package bravozero
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithBandwidthLimit caps the client's upload and download throughput in
+// bytes per second, so background transfers such as SyncDir do not starve
+// the agent's other network traffic. The caps are shared by all requests
+// made through the client. Zero means unlimited.
+//
+// A throttled transfer takes as long as its size requires, so with a limit
+// set, requests are no longer subject to the client's request timeout and
+// are bounded by their ctx only.
+func WithBandwidthLimit(uploadBytesPerSec, downloadBytesPerSec int64) BridgeOption {
+	return func(c *BridgeClient) {
+		if uploadBytesPerSec <= 0 && downloadBytesPerSec <= 0 {
+			return
+		}
+		base := c.httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		c.throttled = true
+		c.httpClient.Transport = &throttledTransport{
+			base:     base,
+			upload:   newBandwidthLimiter(uploadBytesPerSec),
+			download: newBandwidthLimiter(downloadBytesPerSec),
+		}
+	}
+}
+
+type throttledTransport struct {
+	base     http.RoundTripper
+	upload   *bandwidthLimiter
+	download *bandwidthLimiter
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.upload != nil && req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(ctx)
+		req.Body = &throttledReadCloser{ReadCloser: req.Body, ctx: ctx, limiter: t.upload}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.download != nil {
+		resp.Body = &throttledReadCloser{ReadCloser: resp.Body, ctx: ctx, limiter: t.download}
+	}
+	return resp, nil
+}
+
+// bandwidthLimiter is a token bucket refilled at rate bytes per second,
+// holding at most one second's worth of tokens.
+type bandwidthLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newBandwidthLimiter(bytesPerSec int64) *bandwidthLimiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return &bandwidthLimiter{rate: float64(bytesPerSec), tokens: float64(bytesPerSec), last: time.Now()}
+}
+
+// maxChunk is the largest read that can be charged at once.
+func (l *bandwidthLimiter) maxChunk() int {
+	return max(int(l.rate), 1)
+}
+
+// wait charges n bytes and blocks until the bucket is no longer in debt.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type throttledReadCloser struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *bandwidthLimiter
+}
+
+func (r *throttledReadCloser) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.maxChunk() {
+		p = p[:r.limiter.maxChunk()]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if werr := r.limiter.wait(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
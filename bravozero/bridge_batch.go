@@ -0,0 +1,111 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FileOpType is the kind of operation in a BatchOps request.
+type FileOpType string
+
+const (
+	FileOpWrite  FileOpType = "write"
+	FileOpDelete FileOpType = "delete"
+	FileOpCopy   FileOpType = "copy"
+	FileOpMkdir  FileOpType = "mkdir"
+)
+
+// FileOp is one operation in a BatchOps request.
+type FileOp struct {
+	Type FileOpType `json:"type"`
+	Path string     `json:"path"`
+	// Content is the data to write for FileOpWrite.
+	Content string `json:"content,omitempty"`
+	// Destination is the target path for FileOpCopy.
+	Destination string `json:"destination,omitempty"`
+	// CreateDirs creates missing parent directories for FileOpWrite,
+	// FileOpCopy and FileOpMkdir.
+	CreateDirs bool `json:"createDirs,omitempty"`
+	// Recursive deletes a non-empty directory for FileOpDelete.
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// FileOpResult is the outcome of one operation in a BatchOps request.
+type FileOpResult struct {
+	Op FileOp
+	// File is the resulting file for writes, copies and mkdirs.
+	File *FileInfo
+	// Error is set if the operation failed.
+	Error string
+}
+
+// BatchOps executes several file operations server-side in one request and
+// returns a result per operation, in order. Operations run sequentially and
+// a failed operation does not stop later ones; check each result's Error.
+func (c *BridgeClient) BatchOps(ctx context.Context, ops []FileOp) ([]FileOpResult, error) {
+	summary := make([]map[string]interface{}, len(ops))
+	for i, op := range ops {
+		summary[i] = map[string]interface{}{"type": op.Type, "path": op.Path, "destination": op.Destination}
+	}
+	ctx, err := c.guard.check(ctx, "bridge.batch", map[string]interface{}{"ops": summary})
+	if err != nil {
+		return nil, err
+	}
+
+	wire := make([]FileOp, len(ops))
+	for i, op := range ops {
+		remote, err := c.remotePath(ctx, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		wire[i] = op
+		wire[i].Path = remote
+
+		if op.Destination != "" {
+			if wire[i].Destination, err = c.remotePath(ctx, op.Destination); err != nil {
+				return nil, err
+			}
+		}
+		if op.Type == FileOpWrite {
+			stored, err := c.encryptContent(ctx, op.Path, []byte(op.Content))
+			if err != nil {
+				return nil, err
+			}
+			wire[i].Content = string(stored)
+		}
+		c.fileCache.remove(remote)
+	}
+
+	body := map[string][]FileOp{"ops": wire}
+
+	resp, err := c.doRequest(ctx, "POST", "/batch", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Results []struct {
+			File  *rawFileInfo `json:"file"`
+			Error string       `json:"error"`
+		} `json:"results"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(data.Results) != len(ops) {
+		return nil, fmt.Errorf("expected %d results, got %d", len(ops), len(data.Results))
+	}
+
+	results := make([]FileOpResult, len(ops))
+	for i, r := range data.Results {
+		results[i] = FileOpResult{Op: ops[i], Error: r.Error}
+		if r.File != nil {
+			results[i].File = c.fileInfo(ctx, *r.File)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,328 @@
+package bravozero
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileCache is a size-bounded read-through cache of file contents keyed by
+// path and ETag. When attached with WithFileCache, reads send the cached ETag
+// as If-None-Match and are served from the cache when the server answers
+// 304 Not Modified, so unchanged files are never downloaded twice.
+//
+// Entries are held in memory, or on disk if created with NewDirFileCache.
+type FileCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	size     int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// cacheTempMarker marks cache files still being written.
+const cacheTempMarker = ".tmp-"
+
+type cachedFile struct {
+	key string
+	// path is empty for entries loaded from disk by NewDirFileCache, since
+	// the cache file is named by a hash of it.
+	path string
+	etag string
+	size int64
+	// data is nil for disk-backed caches.
+	data []byte
+}
+
+// NewFileCache creates an in-memory cache holding at most maxBytes of file
+// content, evicting the least recently used files.
+func NewFileCache(maxBytes int64) *FileCache {
+	if maxBytes <= 0 {
+		maxBytes = 64 << 20
+	}
+	return &FileCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// NewDirFileCache creates a cache that stores file contents in dir, so they
+// survive restarts. Files already in dir from a previous run are reused.
+func NewDirFileCache(dir string, maxBytes int64) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	fc := NewFileCache(maxBytes)
+	fc.dir = dir
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	type existing struct {
+		entry *cachedFile
+		mtime int64
+	}
+	var found []existing
+	for _, de := range dirEntries {
+		if de.IsDir() {
+			continue
+		}
+		if strings.Contains(de.Name(), cacheTempMarker) {
+			// Left behind by a write interrupted in a previous run.
+			os.Remove(filepath.Join(dir, de.Name()))
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		etag, err := fc.readETag(de.Name())
+		if err != nil {
+			continue
+		}
+		found = append(found, existing{
+			entry: &cachedFile{key: de.Name(), etag: etag, size: info.Size()},
+			mtime: info.ModTime().UnixNano(),
+		})
+	}
+
+	// Oldest first, so the most recently written file ends up at the front.
+	sort.Slice(found, func(i, j int) bool { return found[i].mtime < found[j].mtime })
+	for _, f := range found {
+		fc.entries[f.entry.key] = fc.order.PushFront(f.entry)
+		fc.size += f.entry.size
+	}
+	fc.evict()
+
+	return fc, nil
+}
+
+// WithFileCache caches file contents read through the client in cache.
+func WithFileCache(cache *FileCache) BridgeOption {
+	return func(c *BridgeClient) {
+		c.fileCache = cache
+	}
+}
+
+// Size returns the number of bytes of file content currently cached.
+func (fc *FileCache) Size() int64 {
+	if fc == nil {
+		return 0
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.size
+}
+
+func fileCacheKey(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(sum[:])
+}
+
+// etag returns the cached ETag for path.
+func (fc *FileCache) etag(path string) (string, bool) {
+	if fc == nil {
+		return "", false
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	el, ok := fc.entries[fileCacheKey(path)]
+	if !ok {
+		return "", false
+	}
+	return el.Value.(*cachedFile).etag, true
+}
+
+// get returns the cached content of path if it is cached with etag.
+func (fc *FileCache) get(path, etag string) ([]byte, bool) {
+	if fc == nil {
+		return nil, false
+	}
+	key := fileCacheKey(path)
+
+	fc.mu.Lock()
+	el, ok := fc.entries[key]
+	if !ok || el.Value.(*cachedFile).etag != etag {
+		fc.mu.Unlock()
+		return nil, false
+	}
+	fc.order.MoveToFront(el)
+	data := el.Value.(*cachedFile).data
+	fc.mu.Unlock()
+
+	if fc.dir == "" {
+		return data, true
+	}
+
+	raw, err := os.ReadFile(filepath.Join(fc.dir, key))
+	if err != nil {
+		fc.remove(path)
+		return nil, false
+	}
+	gotETag, content, found := bytes.Cut(raw, []byte("\n"))
+	if !found || string(gotETag) != etag {
+		fc.remove(path)
+		return nil, false
+	}
+	return content, true
+}
+
+func (fc *FileCache) put(path, etag string, data []byte) {
+	if fc == nil || etag == "" || int64(len(data)) > fc.maxBytes {
+		return
+	}
+	key := fileCacheKey(path)
+	entry := &cachedFile{key: key, path: path, etag: etag, size: int64(len(data))}
+
+	if fc.dir == "" {
+		entry.data = data
+	} else {
+		raw := make([]byte, 0, len(etag)+1+len(data))
+		raw = append(append(append(raw, etag...), '\n'), data...)
+		if err := fc.writeFile(key, raw); err != nil {
+			return
+		}
+		entry.size = int64(len(raw))
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if el, ok := fc.entries[key]; ok {
+		fc.size -= el.Value.(*cachedFile).size
+		fc.order.Remove(el)
+	}
+	fc.entries[key] = fc.order.PushFront(entry)
+	fc.size += entry.size
+	fc.evict()
+}
+
+// writeFile stores raw as the cache file key. It writes a temporary file and
+// renames it into place, so a concurrent get never sees a partial entry.
+func (fc *FileCache) writeFile(key string, raw []byte) error {
+	tmp, err := os.CreateTemp(fc.dir, key+cacheTempMarker+"*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(fc.dir, key)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (fc *FileCache) remove(path string) {
+	if fc == nil {
+		return
+	}
+	key := fileCacheKey(path)
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if el, ok := fc.entries[key]; ok {
+		fc.removeElement(el)
+	}
+}
+
+// removeTree removes path and every cached file below it. Entries whose path
+// is unknown are removed too, since they may be below it.
+func (fc *FileCache) removeTree(path string) {
+	if fc == nil {
+		return
+	}
+	prefix := strings.TrimSuffix(path, "/") + "/"
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for el := fc.order.Front(); el != nil; {
+		next := el.Next()
+		entry := el.Value.(*cachedFile)
+		if entry.path == "" || entry.path == path || strings.HasPrefix(entry.path, prefix) {
+			fc.removeElement(el)
+		}
+		el = next
+	}
+}
+
+// evict drops least recently used entries until the cache fits. The caller
+// must hold fc.mu.
+func (fc *FileCache) evict() {
+	for fc.size > fc.maxBytes && fc.order.Len() > 0 {
+		fc.removeElement(fc.order.Back())
+	}
+}
+
+func (fc *FileCache) removeElement(el *list.Element) {
+	entry := el.Value.(*cachedFile)
+	fc.order.Remove(el)
+	delete(fc.entries, entry.key)
+	fc.size -= entry.size
+	if fc.dir != "" {
+		os.Remove(filepath.Join(fc.dir, entry.key))
+	}
+}
+
+func (fc *FileCache) readETag(name string) (string, error) {
+	f, err := os.Open(filepath.Join(fc.dir, name))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// cachingReader passes reads through while buffering the content, and
+// stores it in the cache once the body has been read to the end.
+type cachingReader struct {
+	io.ReadCloser
+	cache *FileCache
+	path  string
+	etag  string
+	buf   bytes.Buffer
+	full  bool
+}
+
+func (r *cachingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if !r.full {
+		if int64(r.buf.Len()+n) > r.cache.maxBytes {
+			r.full = true
+			r.buf = bytes.Buffer{}
+		} else {
+			r.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !r.full {
+		r.cache.put(r.path, r.etag, r.buf.Bytes())
+		r.full = true
+	}
+	return n, err
+}
@@ -0,0 +1,124 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ConflictStrategy selects how ResolveConflict settles a sync conflict.
+type ConflictStrategy string
+
+const (
+	// ResolveOurs keeps the agent's VFS copy.
+	ResolveOurs ConflictStrategy = "ours"
+	// ResolveTheirs keeps the copy from the sync target.
+	ResolveTheirs ConflictStrategy = "theirs"
+	// ResolveMerge merges the two copies line by line. It fails, leaving the
+	// conflict in place, if they changed the same lines.
+	ResolveMerge ConflictStrategy = "merge"
+)
+
+// ConflictedFile is a file whose VFS copy and sync target copy both changed
+// since the last sync.
+type ConflictedFile struct {
+	Path             string    `json:"path"`
+	OursChecksum     string    `json:"oursChecksum"`
+	TheirsChecksum   string    `json:"theirsChecksum"`
+	OursModifiedAt   time.Time `json:"oursModifiedAt"`
+	TheirsModifiedAt time.Time `json:"theirsModifiedAt"`
+	DetectedAt       time.Time `json:"detectedAt"`
+}
+
+// ListConflicts lists the sync conflicts at or below path.
+func (c *BridgeClient) ListConflicts(ctx context.Context, path string) ([]ConflictedFile, error) {
+	if path == "" {
+		path = "/"
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+
+	resp, err := c.doRequest(ctx, "GET", "/sync/conflicts?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Conflicts []struct {
+			Path             string `json:"path"`
+			OursChecksum     string `json:"oursChecksum"`
+			TheirsChecksum   string `json:"theirsChecksum"`
+			OursModifiedAt   string `json:"oursModifiedAt"`
+			TheirsModifiedAt string `json:"theirsModifiedAt"`
+			DetectedAt       string `json:"detectedAt"`
+		} `json:"conflicts"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	conflicts := make([]ConflictedFile, len(data.Conflicts))
+	for i, cf := range data.Conflicts {
+		oursModifiedAt, _ := time.Parse(time.RFC3339, cf.OursModifiedAt)
+		theirsModifiedAt, _ := time.Parse(time.RFC3339, cf.TheirsModifiedAt)
+		detectedAt, _ := time.Parse(time.RFC3339, cf.DetectedAt)
+		conflicts[i] = ConflictedFile{
+			Path:             c.localPath(ctx, cf.Path),
+			OursChecksum:     cf.OursChecksum,
+			TheirsChecksum:   cf.TheirsChecksum,
+			OursModifiedAt:   oursModifiedAt,
+			TheirsModifiedAt: theirsModifiedAt,
+			DetectedAt:       detectedAt,
+		}
+	}
+
+	return conflicts, nil
+}
+
+// ResolveConflict settles the sync conflict on path with strategy and
+// returns the resulting file. The resolved file is synced on the next Sync.
+// Files under an encrypted prefix cannot be merged server-side.
+func (c *BridgeClient) ResolveConflict(ctx context.Context, path string, strategy ConflictStrategy) (*FileInfo, error) {
+	if _, ok := c.encryption.encryptedPrefix(path); ok && strategy == ResolveMerge {
+		return nil, fmt.Errorf("cannot merge encrypted path %s server-side", path)
+	}
+
+	ctx, err := c.guard.check(ctx, "bridge.resolve_conflict", map[string]interface{}{"path": path, "strategy": strategy})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path":     remote,
+		"strategy": strategy,
+	}
+
+	c.fileCache.remove(remote)
+	resp, err := c.doRequest(ctx, "POST", "/sync/conflicts/resolve", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
@@ -0,0 +1,117 @@
+package bravozero
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BulkError reports the paths that failed in a bulk operation such as
+// DownloadAll. Paths not listed succeeded.
+type BulkError struct {
+	Errors map[string]error
+}
+
+func (e *BulkError) Error() string {
+	paths := make([]string, 0, len(e.Errors))
+	for p := range e.Errors {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	msg := fmt.Sprintf("%d of the paths failed", len(paths))
+	for i, p := range paths {
+		if i == 3 {
+			msg += fmt.Sprintf("; and %d more", len(paths)-i)
+			break
+		}
+		msg += fmt.Sprintf("; %s: %v", p, e.Errors[p])
+	}
+	return msg
+}
+
+// Unwrap returns the individual errors, for errors.Is and errors.As.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// DownloadAll downloads the files at paths into destDir, mirroring their VFS
+// paths below it, with at most concurrency downloads in flight (4 if
+// concurrency is not positive). Every path is attempted; if any fail, a
+// *BulkError listing them is returned.
+func (c *BridgeClient) DownloadAll(ctx context.Context, paths []string, destDir string, concurrency int) error {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var (
+		mu       sync.Mutex
+		failures = make(map[string]error)
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+	)
+
+	for _, p := range paths {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			failures[p] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(p string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := c.downloadTo(ctx, p, destDir); err != nil {
+				mu.Lock()
+				failures[p] = err
+				mu.Unlock()
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return &BulkError{Errors: failures}
+	}
+	return nil
+}
+
+func (c *BridgeClient) downloadTo(ctx context.Context, p, destDir string) error {
+	rel := strings.TrimPrefix(pathpkg.Clean("/"+p), "/")
+	dst := filepath.Join(destDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	rc, err := c.Open(ctx, p)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, rc); err != nil {
+		f.Close()
+		os.Remove(dst)
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,190 @@
+package bravozero
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+type bridgeEncryption struct {
+	keyring      Keyring
+	wrapper      KeyWrapper
+	prefixes     []string
+	encryptNames bool
+	nameKeyID    string
+}
+
+// WithEncryptedPaths transparently encrypts the contents of files under the
+// given path prefixes with AES-GCM before they are sent to the VFS, and
+// decrypts them on read. Keys come from keyring.
+func WithEncryptedPaths(keyring Keyring, prefixes ...string) BridgeOption {
+	return func(c *BridgeClient) {
+		if c.encryption == nil {
+			c.encryption = &bridgeEncryption{}
+		}
+		c.encryption.keyring = keyring
+		for _, p := range prefixes {
+			c.encryption.prefixes = append(c.encryption.prefixes, strings.TrimSuffix(p, "/"))
+		}
+	}
+}
+
+// WithEnvelopeEncryption transparently encrypts the contents of files under
+// the given path prefixes with envelope encryption: each write uses a fresh
+// AES-256-GCM data key, which is wrapped by wrapper (e.g. a KMS) and stored
+// with the file. The server never sees plaintext or an unwrapped key.
+//
+// It can be combined with WithEncryptedPaths, in which case new writes use
+// envelope encryption and files written with the keyring remain readable.
+// Filename encryption always uses the keyring.
+func WithEnvelopeEncryption(wrapper KeyWrapper, prefixes ...string) BridgeOption {
+	return func(c *BridgeClient) {
+		if c.encryption == nil {
+			c.encryption = &bridgeEncryption{}
+		}
+		c.encryption.wrapper = wrapper
+		for _, p := range prefixes {
+			c.encryption.prefixes = append(c.encryption.prefixes, strings.TrimSuffix(p, "/"))
+		}
+	}
+}
+
+// WithEncryptedFilenames also encrypts file and directory names below the
+// encrypted prefixes. Names are encrypted deterministically with the keyring
+// key nameKeyID, not the current key, so the same path keeps mapping to the
+// same remote name after the content key is rotated; nameKeyID must stay in
+// the keyring for as long as the files exist. Requires WithEncryptedPaths.
+func WithEncryptedFilenames(nameKeyID string) BridgeOption {
+	return func(c *BridgeClient) {
+		if c.encryption == nil {
+			c.encryption = &bridgeEncryption{}
+		}
+		c.encryption.encryptNames = true
+		c.encryption.nameKeyID = nameKeyID
+	}
+}
+
+// encryptedPrefix returns the configured prefix covering p, if any.
+func (e *bridgeEncryption) encryptedPrefix(p string) (string, bool) {
+	if e == nil || (e.keyring == nil && e.wrapper == nil) {
+		return "", false
+	}
+	for _, prefix := range e.prefixes {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") || prefix == "" {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// Encrypted reports whether path is under a prefix configured with
+// WithEncryptedPaths or WithEnvelopeEncryption. Such files cannot be read by
+// range, appended to, or streamed with Create.
+func (c *BridgeClient) Encrypted(path string) bool {
+	_, ok := c.encryption.encryptedPrefix(path)
+	return ok
+}
+
+// remotePath maps a caller-visible path to the path stored in the VFS.
+func (c *BridgeClient) remotePath(ctx context.Context, p string) (string, error) {
+	prefix, ok := c.encryption.encryptedPrefix(p)
+	if !ok || !c.encryption.encryptNames || c.encryption.keyring == nil || p == prefix {
+		return p, nil
+	}
+
+	keyID := c.encryption.nameKeyID
+	key, err := c.encryption.keyring.Key(ctx, keyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get filename encryption key: %w", err)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(p, prefix+"/"), "/")
+	for i, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		blob, err := sealBlob(keyID, key, []byte(seg), true)
+		if err != nil {
+			return "", err
+		}
+		segments[i] = base64.RawURLEncoding.EncodeToString(blob)
+	}
+	return prefix + "/" + strings.Join(segments, "/"), nil
+}
+
+// localPath maps a VFS path back to the caller-visible path. Segments that
+// cannot be decrypted are returned unchanged.
+func (c *BridgeClient) localPath(ctx context.Context, p string) string {
+	prefix, ok := c.encryption.encryptedPrefix(p)
+	if !ok || !c.encryption.encryptNames || c.encryption.keyring == nil || p == prefix {
+		return p
+	}
+
+	segments := strings.Split(strings.TrimPrefix(p, prefix+"/"), "/")
+	for i, seg := range segments {
+		blob, err := base64.RawURLEncoding.DecodeString(seg)
+		if err != nil {
+			continue
+		}
+		name, err := openBlob(ctx, c.encryption.keyring, blob)
+		if err != nil {
+			continue
+		}
+		segments[i] = string(name)
+	}
+	return prefix + "/" + strings.Join(segments, "/")
+}
+
+// encryptContent encrypts content if p is under an encrypted prefix.
+func (c *BridgeClient) encryptContent(ctx context.Context, p string, content []byte) ([]byte, error) {
+	if _, ok := c.encryption.encryptedPrefix(p); !ok {
+		return content, nil
+	}
+	if c.encryption.wrapper != nil {
+		blob, err := sealEnvelope(ctx, c.encryption.wrapper, content)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(base64.StdEncoding.EncodeToString(blob)), nil
+	}
+	text, err := encryptText(ctx, c.encryption.keyring, content)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(text), nil
+}
+
+// decryptContent decrypts content read from p if p is under an encrypted prefix.
+func (c *BridgeClient) decryptContent(ctx context.Context, p string, content []byte) ([]byte, error) {
+	if _, ok := c.encryption.encryptedPrefix(p); !ok {
+		return content, nil
+	}
+	plaintext, err := c.encryption.decrypt(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", p, err)
+	}
+	return plaintext, nil
+}
+
+// decrypt decrypts base64 text written by either encryption mode.
+func (e *bridgeEncryption) decrypt(ctx context.Context, text []byte) ([]byte, error) {
+	blob := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(blob, text)
+	if err != nil {
+		return nil, errNotEncrypted
+	}
+	blob = blob[:n]
+
+	if bytes.HasPrefix(blob, []byte(envelopeMagic)) {
+		if e.wrapper == nil {
+			return nil, fmt.Errorf("envelope-encrypted data requires WithEnvelopeEncryption")
+		}
+		return openEnvelope(ctx, e.wrapper, blob)
+	}
+	if e.keyring == nil {
+		return nil, fmt.Errorf("keyring-encrypted data requires WithEncryptedPaths")
+	}
+	return openBlob(ctx, e.keyring, blob)
+}
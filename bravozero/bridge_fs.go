@@ -0,0 +1,204 @@
+package bravozero
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FS returns a read-only fs.FS rooted at root in the VFS, so code written
+// against the standard library (fs.WalkDir, template.ParseFS,
+// http.FileServer(http.FS(...))) can read remote files. The returned value
+// also implements fs.ReadDirFS, fs.ReadFileFS and fs.StatFS.
+//
+// fs.FS methods take no context, so requests are made with
+// context.Background() and are bounded only by the client's timeout.
+func (c *BridgeClient) FS(root string) fs.FS {
+	if root == "" {
+		root = "/"
+	}
+	return &bridgeFS{client: c, root: root}
+}
+
+type bridgeFS struct {
+	client *BridgeClient
+	root   string
+}
+
+func (b *bridgeFS) fullPath(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return path.Join(b.root, name), nil
+}
+
+// Open implements fs.FS.
+func (b *bridgeFS) Open(name string) (fs.File, error) {
+	info, err := b.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &bridgeDir{fsys: b, name: name, info: info}, nil
+	}
+
+	full, _ := b.fullPath("open", name)
+	rc, err := b.client.Open(context.Background(), full)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &bridgeFile{ReadCloser: rc, info: info}, nil
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (b *bridgeFS) ReadFile(name string) ([]byte, error) {
+	full, err := b.fullPath("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := b.client.ReadFileBytes(context.Background(), full)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	return data, nil
+}
+
+// ReadDir implements fs.ReadDirFS. Entries are sorted by name.
+func (b *bridgeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	full, err := b.fullPath("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	listing, err := b.client.ListFiles(context.Background(), full, false, "")
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries := make([]fs.DirEntry, len(listing.Files))
+	for i, f := range listing.Files {
+		entries[i] = fs.FileInfoToDirEntry(bridgeFileInfo{f})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// Stat implements fs.StatFS. The VFS has no stat endpoint, so the parent
+// directory is listed and searched for name.
+func (b *bridgeFS) Stat(name string) (fs.FileInfo, error) {
+	full, err := b.fullPath("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	if name == "." {
+		return bridgeFileInfo{FileInfo{Path: full, Name: path.Base(full), IsDirectory: true}}, nil
+	}
+
+	listing, err := b.client.ListFiles(context.Background(), path.Dir(full), false, "")
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	base := path.Base(full)
+	for _, f := range listing.Files {
+		if f.Name == base {
+			return bridgeFileInfo{f}, nil
+		}
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// bridgeFileInfo adapts FileInfo to fs.FileInfo.
+type bridgeFileInfo struct {
+	info FileInfo
+}
+
+func (fi bridgeFileInfo) Name() string       { return fi.info.Name }
+func (fi bridgeFileInfo) Size() int64        { return fi.info.Size }
+func (fi bridgeFileInfo) ModTime() time.Time { return fi.info.ModifiedAt }
+func (fi bridgeFileInfo) IsDir() bool        { return fi.info.IsDirectory }
+func (fi bridgeFileInfo) Sys() interface{}   { return &fi.info }
+
+func (fi bridgeFileInfo) Mode() fs.FileMode {
+	mode := parsePermissions(fi.info.Permissions, fi.info.IsDirectory)
+	if fi.info.IsDirectory {
+		mode |= fs.ModeDir
+	}
+	return mode
+}
+
+// parsePermissions parses octal ("0644") or symbolic ("rw-r--r--")
+// permissions, falling back to 0755 for directories and 0644 for files.
+func parsePermissions(p string, isDir bool) fs.FileMode {
+	if n, err := strconv.ParseUint(p, 8, 32); err == nil {
+		return fs.FileMode(n) & fs.ModePerm
+	}
+	if len(p) == 10 {
+		p = p[1:]
+	}
+	if len(p) == 9 {
+		var mode fs.FileMode
+		for i, ch := range p {
+			if ch != '-' {
+				mode |= 1 << uint(8-i)
+			}
+		}
+		return mode
+	}
+	if isDir {
+		return 0o755
+	}
+	return 0o644
+}
+
+type bridgeFile struct {
+	io.ReadCloser
+	info fs.FileInfo
+}
+
+func (f *bridgeFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+// bridgeDir is an open directory. Entries are fetched on the first ReadDir.
+type bridgeDir struct {
+	fsys    *bridgeFS
+	name    string
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	loaded  bool
+	offset  int
+}
+
+func (d *bridgeDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *bridgeDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *bridgeDir) Close() error { return nil }
+
+func (d *bridgeDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.loaded {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.loaded = true
+	}
+
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
@@ -0,0 +1,86 @@
+package bravozero
+
+import (
+	"context"
+	"io/fs"
+	pathpkg "path"
+	"strings"
+)
+
+// Glob returns the files and directories in the VFS matching pattern. Pattern
+// segments use path.Match syntax, and a "**" segment matches zero or more
+// directories, e.g. "/src/**/*_test.go". Only the subtree below the
+// pattern's longest literal prefix is listed.
+func (c *BridgeClient) Glob(ctx context.Context, pattern string) ([]FileInfo, error) {
+	pattern = pathpkg.Clean("/" + pattern)
+	if _, err := pathpkg.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return nil, err
+	}
+
+	segments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	literal := 0
+	for literal < len(segments)-1 && !hasGlobMeta(segments[literal]) {
+		literal++
+	}
+	root := "/" + strings.Join(segments[:literal], "/")
+	remaining := segments[literal:]
+
+	recursive := false
+	for _, seg := range remaining {
+		if seg == "**" {
+			recursive = true
+		}
+	}
+
+	var matches []FileInfo
+	err := c.Walk(ctx, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+
+		rel := strings.Split(strings.TrimPrefix(strings.TrimPrefix(p, root), "/"), "/")
+		if matchSegments(remaining, rel) {
+			info, _ := d.Info()
+			matches = append(matches, *info.Sys().(*FileInfo))
+		}
+		if d.IsDir() && !recursive && len(rel) >= len(remaining) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, `*?[\`)
+}
+
+// matchSegments matches path segments against pattern segments, where a "**"
+// pattern segment matches any number of path segments.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := pathpkg.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,62 @@
+package bravozero
+
+import (
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// checksumHeader carries the hex SHA-256 of a download, as a header or, for
+// streamed responses, a trailer.
+const checksumHeader = "X-Content-Sha256"
+
+// WithVerifyChecksum verifies a download against the SHA-256 checksum the
+// server sends in the X-Content-Sha256 header or trailer, failing with an
+// *IntegrityError on mismatch or if no checksum is sent. It is accepted by
+// Open and ReadFileBytes. Content served from a FileCache is not re-verified.
+func WithVerifyChecksum() TransferOption {
+	return func(c *transferConfig) {
+		c.verify = true
+	}
+}
+
+// verifyingReader hashes content as it is read and checks it against the
+// server's checksum at EOF, when any trailer has also been received.
+type verifyingReader struct {
+	io.ReadCloser
+	resp *http.Response
+	path string
+	hash hash.Hash
+	err  error
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if verr := r.verify(); verr != nil {
+			r.err = verr
+			return n, verr
+		}
+	}
+	return n, err
+}
+
+func (r *verifyingReader) verify() error {
+	expected := r.resp.Header.Get(checksumHeader)
+	if expected == "" {
+		expected = r.resp.Trailer.Get(checksumHeader)
+	}
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	actual := hex.EncodeToString(r.hash.Sum(nil))
+
+	if expected == "" || expected != actual {
+		return &IntegrityError{Path: r.path, Expected: expected, Actual: actual}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FileLock is an advisory lock on a VFS file. Locks are only honoured by
+// cooperating agents that call Lock; they do not block plain writes.
+type FileLock struct {
+	// Token identifies the lock and must be passed to Unlock. It is only
+	// returned to the agent holding the lock.
+	Token      string    `json:"token,omitempty"`
+	Path       string    `json:"path"`
+	HeldBy     string    `json:"heldBy"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+type rawFileLock struct {
+	Token      string `json:"token"`
+	Path       string `json:"path"`
+	HeldBy     string `json:"heldBy"`
+	AcquiredAt string `json:"acquiredAt"`
+	ExpiresAt  string `json:"expiresAt"`
+}
+
+func (l *rawFileLock) toFileLock() *FileLock {
+	if l == nil {
+		return nil
+	}
+	acquiredAt, _ := time.Parse(time.RFC3339, l.AcquiredAt)
+	expiresAt, _ := time.Parse(time.RFC3339, l.ExpiresAt)
+
+	return &FileLock{
+		Token:      l.Token,
+		Path:       l.Path,
+		HeldBy:     l.HeldBy,
+		AcquiredAt: acquiredAt,
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// Lock acquires an advisory lock on path that expires after ttl unless
+// released with Unlock first. If another agent holds the lock a
+// *FileLockedError is returned. Locking a path the caller already holds
+// extends the lock. The server works in whole seconds, so ttl is rounded up.
+func (c *BridgeClient) Lock(ctx context.Context, path string, ttl time.Duration) (*FileLock, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lock TTL must be positive")
+	}
+	ttlSeconds := int((ttl + time.Second - 1) / time.Second)
+
+	ctx, err := c.guard.check(ctx, "bridge.lock", map[string]interface{}{"path": path, "ttlSeconds": ttlSeconds})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path":       remote,
+		"ttlSeconds": ttlSeconds,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/locks", body)
+	if err != nil {
+		var locked *FileLockedError
+		if errors.As(err, &locked) {
+			locked.Path = path
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileLock
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	lock := data.toFileLock()
+	lock.Path = path
+	return lock, nil
+}
+
+// Unlock releases a lock acquired with Lock.
+func (c *BridgeClient) Unlock(ctx context.Context, path, token string) error {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{
+		"path":  remote,
+		"token": token,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/locks/release", body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
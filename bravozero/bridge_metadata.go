@@ -0,0 +1,75 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// SetFileMetadata replaces the key-value annotations on a file, such as the
+// build that produced it or the agent that owns it. Unlike SetAttributes,
+// keys missing from metadata are removed; pass nil to clear all metadata.
+func (c *BridgeClient) SetFileMetadata(ctx context.Context, path string, metadata map[string]string) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.set_metadata", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	body := map[string]interface{}{
+		"path":     remote,
+		"metadata": metadata,
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", "/file/metadata", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
+
+// GetFileMetadata returns the key-value annotations on a file. A file without
+// metadata returns an empty map.
+func (c *BridgeClient) GetFileMetadata(ctx context.Context, path string) (map[string]string, error) {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+
+	resp, err := c.doRequest(ctx, "GET", "/file/metadata?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if data.Metadata == nil {
+		data.Metadata = map[string]string{}
+	}
+
+	return data.Metadata, nil
+}
@@ -0,0 +1,128 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// OperationStatus is the state of a long-running operation.
+type OperationStatus string
+
+const (
+	OperationPending   OperationStatus = "pending"
+	OperationRunning   OperationStatus = "running"
+	OperationSucceeded OperationStatus = "succeeded"
+	OperationFailed    OperationStatus = "failed"
+)
+
+// Operation is a long-running server-side task.
+type Operation struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Status      OperationStatus `json:"status"`
+	Progress    float64         `json:"progress"`
+	Error       string          `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	CompletedAt time.Time       `json:"completedAt,omitempty"`
+}
+
+// Done reports whether the operation has finished, successfully or not.
+func (o *Operation) Done() bool {
+	return o.Status == OperationSucceeded || o.Status == OperationFailed
+}
+
+// CopyBetweenWorkspaces copies srcPath in srcWorkspace to dstPath in
+// dstWorkspace entirely server-side. It returns immediately with a
+// long-running Operation; use WaitOperation to block until it completes.
+func (c *BridgeClient) CopyBetweenWorkspaces(ctx context.Context, srcWorkspace, srcPath, dstWorkspace, dstPath string) (*Operation, error) {
+	ctx, err := c.guard.check(ctx, "bridge.copy_workspace", map[string]interface{}{"sourceWorkspace": srcWorkspace, "sourcePath": srcPath, "destinationWorkspace": dstWorkspace, "destinationPath": dstPath})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{
+		"sourceWorkspace":      srcWorkspace,
+		"sourcePath":           srcPath,
+		"destinationWorkspace": dstWorkspace,
+		"destinationPath":      dstPath,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/workspaces/copy", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseOperation(resp.Body)
+}
+
+// GetOperation retrieves the current state of a long-running operation.
+func (c *BridgeClient) GetOperation(ctx context.Context, operationID string) (*Operation, error) {
+	resp, err := c.doRequest(ctx, "GET", "/operations/"+url.PathEscape(operationID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseOperation(resp.Body)
+}
+
+// WaitOperation polls an operation every pollInterval until it completes or
+// ctx is done. A failed operation is returned together with an error.
+func (c *BridgeClient) WaitOperation(ctx context.Context, operationID string, pollInterval time.Duration) (*Operation, error) {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	for {
+		op, err := c.GetOperation(ctx, operationID)
+		if err != nil {
+			return nil, err
+		}
+		if op.Status == OperationFailed {
+			return op, fmt.Errorf("operation %s failed: %s", op.ID, op.Error)
+		}
+		if op.Done() {
+			return op, nil
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return op, ctx.Err()
+		}
+	}
+}
+
+func parseOperation(r io.Reader) (*Operation, error) {
+	var data struct {
+		ID          string  `json:"id"`
+		Type        string  `json:"type"`
+		Status      string  `json:"status"`
+		Progress    float64 `json:"progress"`
+		Error       string  `json:"error"`
+		CreatedAt   string  `json:"createdAt"`
+		CompletedAt string  `json:"completedAt"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, data.CreatedAt)
+	completedAt, _ := time.Parse(time.RFC3339, data.CompletedAt)
+
+	return &Operation{
+		ID:          data.ID,
+		Type:        data.Type,
+		Status:      OperationStatus(data.Status),
+		Progress:    data.Progress,
+		Error:       data.Error,
+		CreatedAt:   createdAt,
+		CompletedAt: completedAt,
+	}, nil
+}
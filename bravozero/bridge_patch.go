@@ -0,0 +1,80 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DiffFile returns a unified diff from the file's current content to
+// newContent, computed server-side so only newContent is uploaded. An empty
+// diff means the contents are identical.
+func (c *BridgeClient) DiffFile(ctx context.Context, path, newContent string) (string, error) {
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return "", fmt.Errorf("cannot diff encrypted path %s server-side", path)
+	}
+
+	body := map[string]string{
+		"path":    path,
+		"content": newContent,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/file/diff", body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Diff string `json:"diff"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.Diff, nil
+}
+
+// ApplyPatch applies a unified diff to the file server-side and returns the
+// updated file's info. The patch is rejected if any hunk fails to apply.
+// WithIfMatch can be used to guard against the file having changed since
+// the patch was produced.
+func (c *BridgeClient) ApplyPatch(ctx context.Context, path, patch string, opts ...WriteOption) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.patch", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return nil, fmt.Errorf("cannot patch encrypted path %s server-side", path)
+	}
+
+	cfg := transferConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	body := map[string]string{
+		"path":  path,
+		"patch": patch,
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, "POST", "/file/patch", body, cfg.headers())
+	if err != nil {
+		var precondition *PreconditionFailedError
+		if errors.As(err, &precondition) {
+			precondition.Path = path
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
@@ -0,0 +1,50 @@
+package bravozero
+
+import (
+	"context"
+	"io"
+)
+
+// ProgressFunc is called as a transfer proceeds with the number of bytes
+// transferred so far and the total size, or -1 if the size is unknown.
+type ProgressFunc func(transferred, total int64)
+
+// WithProgress reports the progress of an upload or download to fn. It is
+// accepted by WriteFile, Open, ReadFileBytes and DownloadArchive. fn is
+// called from the goroutine doing the transfer and should return quickly.
+func WithProgress(fn ProgressFunc) TransferOption {
+	return func(c *transferConfig) {
+		c.progress = fn
+	}
+}
+
+type uploadProgressKey struct{}
+
+func withUploadProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	if fn == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, uploadProgressKey{}, fn)
+}
+
+func uploadProgressFromContext(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(uploadProgressKey{}).(ProgressFunc)
+	return fn
+}
+
+// progressReadCloser reports cumulative bytes read to fn.
+type progressReadCloser struct {
+	io.ReadCloser
+	fn          ProgressFunc
+	total       int64
+	transferred int64
+}
+
+func (r *progressReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.transferred += int64(n)
+		r.fn(r.transferred, r.total)
+	}
+	return n, err
+}
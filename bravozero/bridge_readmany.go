@@ -0,0 +1,64 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ReadFiles reads several files in a single request, returning their
+// contents keyed by path. It is intended for small files such as prompts
+// and configuration. Files that could not be read are left out of the map
+// and reported in a *BulkError, returned alongside the files that were read.
+func (c *BridgeClient) ReadFiles(ctx context.Context, paths []string) (map[string][]byte, error) {
+	remotes := make([]string, len(paths))
+	for i, p := range paths {
+		remote, err := c.remotePath(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		remotes[i] = remote
+	}
+
+	body := map[string][]string{"paths": remotes}
+
+	resp, err := c.doRequest(ctx, "POST", "/files/read", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Files []struct {
+			Path    string `json:"path"`
+			Content []byte `json:"content"`
+			Error   string `json:"error"`
+		} `json:"files"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	files := make(map[string][]byte, len(data.Files))
+	failures := make(map[string]error)
+	for _, f := range data.Files {
+		path := c.localPath(ctx, f.Path)
+		if f.Error != "" {
+			failures[path] = errors.New(f.Error)
+			continue
+		}
+		content, err := c.decryptContent(ctx, path, f.Content)
+		if err != nil {
+			failures[path] = err
+			continue
+		}
+		files[path] = content
+	}
+
+	if len(failures) > 0 {
+		return files, &BulkError{Errors: failures}
+	}
+	return files, nil
+}
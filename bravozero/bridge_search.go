@@ -0,0 +1,102 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SearchMatch is a file containing at least one hit for SearchContent.
+type SearchMatch struct {
+	Path string      `json:"path"`
+	Hits []SearchHit `json:"hits"`
+}
+
+// SearchHit is a single matching line.
+type SearchHit struct {
+	// Line is 1-based.
+	Line int `json:"line"`
+	// Column is the 1-based byte offset of the match within Text.
+	Column int    `json:"column"`
+	Text   string `json:"text"`
+}
+
+type searchConfig struct {
+	Regex         bool   `json:"regex,omitempty"`
+	CaseSensitive bool   `json:"caseSensitive,omitempty"`
+	Pattern       string `json:"pattern,omitempty"`
+	MaxResults    int    `json:"maxResults,omitempty"`
+}
+
+// SearchOption configures SearchContent.
+type SearchOption func(*searchConfig)
+
+// WithRegex treats the query as an RE2 regular expression rather than a
+// literal string.
+func WithRegex() SearchOption {
+	return func(c *searchConfig) {
+		c.Regex = true
+	}
+}
+
+// WithCaseSensitive makes the search case-sensitive; it is insensitive by default.
+func WithCaseSensitive() SearchOption {
+	return func(c *searchConfig) {
+		c.CaseSensitive = true
+	}
+}
+
+// WithSearchPattern only searches files whose names match the glob pattern.
+func WithSearchPattern(pattern string) SearchOption {
+	return func(c *searchConfig) {
+		c.Pattern = pattern
+	}
+}
+
+// WithMaxResults caps the number of matching files returned.
+func WithMaxResults(n int) SearchOption {
+	return func(c *searchConfig) {
+		c.MaxResults = n
+	}
+}
+
+// SearchContent searches the contents of files under path using the Bridge's
+// server-side index and returns the matching files with their line hits.
+// Files under an encrypted prefix are stored as ciphertext and never match.
+func (c *BridgeClient) SearchContent(ctx context.Context, path, query string, opts ...SearchOption) ([]SearchMatch, error) {
+	cfg := searchConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		Path  string `json:"path"`
+		Query string `json:"query"`
+		searchConfig
+	}{remote, query, cfg}
+
+	resp, err := c.doRequest(ctx, "POST", "/search", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Matches []SearchMatch `json:"matches"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for i := range data.Matches {
+		data.Matches[i].Path = c.localPath(ctx, data.Matches[i].Path)
+	}
+
+	return data.Matches, nil
+}
@@ -0,0 +1,139 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ShareMode is the level of access granted on a shared path.
+type ShareMode string
+
+const (
+	ShareRead      ShareMode = "read"
+	ShareReadWrite ShareMode = "read_write"
+)
+
+// FileShare gives another agent access to a VFS path. Sharing a directory
+// shares everything below it.
+type FileShare struct {
+	Path      string    `json:"path"`
+	AgentID   string    `json:"agentId"`
+	Mode      ShareMode `json:"mode"`
+	GrantedBy string    `json:"grantedBy"`
+	GrantedAt time.Time `json:"grantedAt"`
+}
+
+type rawFileShare struct {
+	Path      string `json:"path"`
+	AgentID   string `json:"agentId"`
+	Mode      string `json:"mode"`
+	GrantedBy string `json:"grantedBy"`
+	GrantedAt string `json:"grantedAt"`
+}
+
+func (c *BridgeClient) fileShare(ctx context.Context, s rawFileShare) FileShare {
+	grantedAt, _ := time.Parse(time.RFC3339, s.GrantedAt)
+	return FileShare{
+		Path:      c.localPath(ctx, s.Path),
+		AgentID:   s.AgentID,
+		Mode:      ShareMode(s.Mode),
+		GrantedBy: s.GrantedBy,
+		GrantedAt: grantedAt,
+	}
+}
+
+// Share grants agentID access to path, replacing any existing share of that
+// path with the agent. Files under an encrypted prefix can be shared, but the
+// other agent needs the key to read them.
+func (c *BridgeClient) Share(ctx context.Context, path, agentID string, mode ShareMode) (*FileShare, error) {
+	ctx, err := c.guard.check(ctx, "bridge.share", map[string]interface{}{"path": path, "agentId": agentID, "mode": mode})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path":    remote,
+		"agentId": agentID,
+		"mode":    mode,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/shares", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileShare
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	share := c.fileShare(ctx, data)
+	return &share, nil
+}
+
+// ListShares lists the shares on path and everything below it. An empty path
+// lists all of the caller's shares.
+func (c *BridgeClient) ListShares(ctx context.Context, path string) ([]FileShare, error) {
+	params := url.Values{}
+	if path != "" {
+		remote, err := c.remotePath(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("path", remote)
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/shares?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Shares []rawFileShare `json:"shares"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	shares := make([]FileShare, len(data.Shares))
+	for i, s := range data.Shares {
+		shares[i] = c.fileShare(ctx, s)
+	}
+
+	return shares, nil
+}
+
+// Unshare removes agentID's access to path.
+func (c *BridgeClient) Unshare(ctx context.Context, path, agentID string) error {
+	ctx, err := c.guard.check(ctx, "bridge.unshare", map[string]interface{}{"path": path, "agentId": agentID})
+	if err != nil {
+		return err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+	params.Set("agentId", agentID)
+
+	resp, err := c.doRequest(ctx, "DELETE", "/shares?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
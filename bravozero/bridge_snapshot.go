@@ -0,0 +1,121 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DirSnapshot is a point-in-time capture of a VFS subtree.
+type DirSnapshot struct {
+	ID        string    `json:"id"`
+	Path      string    `json:"path"`
+	FileCount int       `json:"fileCount"`
+	TotalSize int64     `json:"totalSize"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type rawDirSnapshot struct {
+	ID        string `json:"id"`
+	Path      string `json:"path"`
+	FileCount int    `json:"fileCount"`
+	TotalSize int64  `json:"totalSize"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (c *BridgeClient) dirSnapshot(ctx context.Context, s rawDirSnapshot) DirSnapshot {
+	createdAt, _ := time.Parse(time.RFC3339, s.CreatedAt)
+	return DirSnapshot{
+		ID:        s.ID,
+		Path:      c.localPath(ctx, s.Path),
+		FileCount: s.FileCount,
+		TotalSize: s.TotalSize,
+		CreatedAt: createdAt,
+	}
+}
+
+// SnapshotDir captures the current state of the subtree at path so it can be
+// rolled back with RestoreDir, e.g. before a risky multi-file edit. Snapshots
+// are copy-on-write server-side and cheap to take.
+func (c *BridgeClient) SnapshotDir(ctx context.Context, path string) (*DirSnapshot, error) {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{"path": remote}
+
+	resp, err := c.doRequest(ctx, "POST", "/snapshots", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawDirSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	snapshot := c.dirSnapshot(ctx, data)
+	return &snapshot, nil
+}
+
+// ListDirSnapshots lists the snapshots taken of path, newest first.
+func (c *BridgeClient) ListDirSnapshots(ctx context.Context, path string) ([]DirSnapshot, error) {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+
+	resp, err := c.doRequest(ctx, "GET", "/snapshots?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Snapshots []rawDirSnapshot `json:"snapshots"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	snapshots := make([]DirSnapshot, len(data.Snapshots))
+	for i, s := range data.Snapshots {
+		snapshots[i] = c.dirSnapshot(ctx, s)
+	}
+
+	return snapshots, nil
+}
+
+// RestoreDir replaces the subtree at targetPath with the snapshot's content,
+// removing files created since it was taken. An empty targetPath restores
+// the snapshot to the path it was taken of; any other path receives a copy.
+func (c *BridgeClient) RestoreDir(ctx context.Context, snapshotID, targetPath string) error {
+	ctx, err := c.guard.check(ctx, "bridge.restore_dir", map[string]interface{}{"snapshotId": snapshotID, "targetPath": targetPath})
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{}
+	if targetPath != "" {
+		remote, err := c.remotePath(ctx, targetPath)
+		if err != nil {
+			return err
+		}
+		body["targetPath"] = remote
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/snapshots/"+url.PathEscape(snapshotID)+"/restore", body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,182 @@
+package bravozero
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// copyBufferSize is the buffer used by the streaming fast paths. The default
+// 32KiB used by io.Copy costs a lot of syscalls for large artifacts.
+const copyBufferSize = 1 << 20
+
+// defaultPartSize is used when the server does not suggest a part size.
+const defaultPartSize = 8 << 20
+
+// fileReader is the reader returned by Open. It implements io.WriterTo so
+// io.Copy moves data in large chunks.
+type fileReader struct {
+	io.ReadCloser
+}
+
+// WriteTo implements io.WriterTo.
+func (r *fileReader) WriteTo(w io.Writer) (int64, error) {
+	// Hide any ReaderFrom on w: *os.File would fall back to a 32KiB copy,
+	// since an HTTP body cannot be spliced.
+	return io.CopyBuffer(writerOnly{w}, r.ReadCloser, make([]byte, copyBufferSize))
+}
+
+type writerOnly struct {
+	io.Writer
+}
+
+// FileWriter streams a file to the VFS as a multipart upload. It implements
+// io.ReaderFrom so io.Copy from a file or network connection reads whole
+// parts at a time. Nothing is visible at the destination until Close
+// succeeds.
+type FileWriter struct {
+	client  *BridgeClient
+	ctx     context.Context
+	session *UploadSession
+	buf     []byte
+	parts   []UploadedPart
+	info    *FileInfo
+	closed  bool
+	err     error
+}
+
+// Create starts streaming a new file to path. Write the content to the
+// returned FileWriter, then Close it to complete the upload, or Abort it to
+// discard what was written. Files under an encrypted prefix cannot be
+// streamed; use WriteFile.
+func (c *BridgeClient) Create(ctx context.Context, path string, createDirs bool) (*FileWriter, error) {
+	ctx, err := c.guard.check(ctx, "bridge.write", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := c.startUpload(ctx, path, createDirs)
+	if err != nil {
+		return nil, err
+	}
+	if session.PartSize <= 0 {
+		session.PartSize = defaultPartSize
+	}
+	return &FileWriter{
+		client:  c,
+		ctx:     ctx,
+		session: session,
+		buf:     make([]byte, 0, session.PartSize),
+	}, nil
+}
+
+// Write implements io.Writer. Data is uploaded each time a full part has
+// been buffered.
+func (w *FileWriter) Write(p []byte) (int, error) {
+	if err := w.usable(); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), cap(w.buf)-len(w.buf))
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		written += n
+
+		if len(w.buf) == cap(w.buf) {
+			if err := w.uploadPart(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// ReadFrom implements io.ReaderFrom, reading r a part at a time directly
+// into the upload buffer.
+func (w *FileWriter) ReadFrom(r io.Reader) (int64, error) {
+	if err := w.usable(); err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for {
+		n, err := io.ReadFull(r, w.buf[len(w.buf):cap(w.buf)])
+		w.buf = w.buf[:len(w.buf)+n]
+		total += int64(n)
+
+		if len(w.buf) == cap(w.buf) {
+			if uerr := w.uploadPart(); uerr != nil {
+				return total, uerr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// Close uploads any buffered data and completes the upload. If completion
+// fails the upload is aborted.
+func (w *FileWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+	if w.err != nil {
+		w.Abort()
+		return w.err
+	}
+	w.closed = true
+
+	if len(w.buf) > 0 || len(w.parts) == 0 {
+		if err := w.uploadPart(); err != nil {
+			w.client.AbortUpload(w.ctx, w.session.ID)
+			return err
+		}
+	}
+
+	info, err := w.client.completeUpload(w.ctx, w.session.ID, w.parts)
+	if err != nil {
+		w.err = err
+		w.client.AbortUpload(w.ctx, w.session.ID)
+		return err
+	}
+	w.info = info
+	return nil
+}
+
+// Abort discards the upload.
+func (w *FileWriter) Abort() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.client.AbortUpload(w.ctx, w.session.ID)
+}
+
+// Info returns the uploaded file's info once Close has succeeded.
+func (w *FileWriter) Info() *FileInfo {
+	return w.info
+}
+
+func (w *FileWriter) usable() error {
+	if w.closed {
+		return errors.New("write to closed FileWriter")
+	}
+	return w.err
+}
+
+func (w *FileWriter) uploadPart() error {
+	part, err := w.client.uploadPart(w.ctx, w.session.ID, len(w.parts)+1, w.buf)
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.parts = append(w.parts, *part)
+	w.buf = w.buf[:0]
+	return nil
+}
@@ -0,0 +1,427 @@
+package bravozero
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncStateFile is the file SyncDir keeps in the local directory to remember
+// what each file looked like when last synchronized, so deletions and
+// one-sided edits can be told apart from conflicts.
+const SyncStateFile = ".bravozero-sync.json"
+
+// ConflictPolicy decides how SyncDir resolves a file changed on both sides.
+type ConflictPolicy string
+
+const (
+	// ConflictNewest keeps whichever side was modified most recently.
+	ConflictNewest ConflictPolicy = "newest"
+	// ConflictPreferLocal overwrites the remote file with the local one.
+	ConflictPreferLocal ConflictPolicy = "prefer_local"
+	// ConflictPreferRemote overwrites the local file with the remote one.
+	ConflictPreferRemote ConflictPolicy = "prefer_remote"
+	// ConflictSkip leaves both sides untouched and reports the conflict.
+	ConflictSkip ConflictPolicy = "skip"
+)
+
+// SyncConflict is a file that changed on both sides.
+type SyncConflict struct {
+	Path string
+	// Resolution is "local", "remote" or "skipped".
+	Resolution string
+}
+
+// SyncDirReport lists what SyncDir changed, as paths relative to the synced
+// directories.
+type SyncDirReport struct {
+	Uploaded      []string
+	Downloaded    []string
+	DeletedLocal  []string
+	DeletedRemote []string
+	Conflicts     []SyncConflict
+}
+
+type syncDirConfig struct {
+	include []string
+	exclude []string
+	policy  ConflictPolicy
+	dryRun  bool
+}
+
+// SyncDirOption configures SyncDir.
+type SyncDirOption func(*syncDirConfig)
+
+// WithSyncInclude only synchronizes files whose relative path or base name
+// matches one of the glob patterns (path.Match syntax).
+func WithSyncInclude(patterns ...string) SyncDirOption {
+	return func(c *syncDirConfig) {
+		c.include = append(c.include, patterns...)
+	}
+}
+
+// WithSyncExclude skips files whose relative path or base name matches one of
+// the glob patterns. A pattern ending in "/" excludes a whole directory.
+func WithSyncExclude(patterns ...string) SyncDirOption {
+	return func(c *syncDirConfig) {
+		c.exclude = append(c.exclude, patterns...)
+	}
+}
+
+// WithConflictPolicy sets how files changed on both sides are resolved. The
+// default is ConflictNewest.
+func WithConflictPolicy(policy ConflictPolicy) SyncDirOption {
+	return func(c *syncDirConfig) {
+		c.policy = policy
+	}
+}
+
+// WithSyncDryRun reports what SyncDir would do without changing anything.
+func WithSyncDryRun() SyncDirOption {
+	return func(c *syncDirConfig) {
+		c.dryRun = true
+	}
+}
+
+func (c *syncDirConfig) selected(rel string) bool {
+	base := pathpkg.Base(rel)
+	for _, p := range c.exclude {
+		if strings.HasSuffix(p, "/") {
+			dir := strings.TrimSuffix(p, "/")
+			if rel == dir || strings.HasPrefix(rel, dir+"/") || strings.Contains(rel, "/"+dir+"/") {
+				return false
+			}
+			continue
+		}
+		if globMatch(p, rel) || globMatch(p, base) {
+			return false
+		}
+	}
+	if len(c.include) == 0 {
+		return true
+	}
+	for _, p := range c.include {
+		if globMatch(p, rel) || globMatch(p, base) {
+			return true
+		}
+	}
+	return false
+}
+
+func globMatch(pattern, name string) bool {
+	ok, _ := pathpkg.Match(pattern, name)
+	return ok
+}
+
+// syncedFile records a file's state on both sides at the last sync.
+type syncedFile struct {
+	LocalHash     string `json:"localHash"`
+	RemoteVersion string `json:"remoteVersion"`
+}
+
+type localFile struct {
+	hash  string
+	mtime time.Time
+}
+
+// SyncDir mirrors localDir and the VFS subtree at remotePath in both
+// directions. A file changed on one side since the last SyncDir is copied to
+// the other; a file deleted on one side is deleted on the other; a file
+// changed on both sides is resolved by the conflict policy. On the first
+// sync, files present on only one side are copied and files that differ are
+// treated as conflicts.
+//
+// Local files are compared by SHA-256, remote files by FileInfo.Checksum, or
+// by size and modification time if the server provides no checksum. State
+// is kept in SyncStateFile inside localDir. Empty directories are not synced.
+//
+// Files are synced in path order. SyncDir stops at the first error, saving
+// the state of the files synced so far, and returns the report of what it
+// had done.
+func (c *BridgeClient) SyncDir(ctx context.Context, localDir, remotePath string, opts ...SyncDirOption) (*SyncDirReport, error) {
+	cfg := &syncDirConfig{policy: ConflictNewest}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.exclude = append(cfg.exclude, SyncStateFile)
+
+	remoteRoot := pathpkg.Clean("/" + remotePath)
+	report := &SyncDirReport{}
+
+	local, err := scanLocalDir(localDir, cfg)
+	if err != nil {
+		return report, err
+	}
+	remote, err := c.scanRemoteDir(ctx, remoteRoot, cfg)
+	if err != nil {
+		return report, err
+	}
+	state, err := loadSyncState(localDir)
+	if err != nil {
+		return report, err
+	}
+
+	seen := make(map[string]bool)
+	for rel := range local {
+		seen[rel] = true
+	}
+	for rel := range remote {
+		seen[rel] = true
+	}
+	for rel := range state {
+		seen[rel] = true
+	}
+	paths := make([]string, 0, len(seen))
+	for rel := range seen {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	newState := make(map[string]syncedFile)
+	push := func(rel string) error {
+		report.Uploaded = append(report.Uploaded, rel)
+		if cfg.dryRun {
+			return nil
+		}
+		data, err := os.ReadFile(filepath.Join(localDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		info, err := c.WriteFileBytes(ctx, pathpkg.Join(remoteRoot, rel), data, true, WithMtime(local[rel].mtime))
+		if err != nil {
+			return err
+		}
+		newState[rel] = syncedFile{LocalHash: local[rel].hash, RemoteVersion: remoteVersion(*info)}
+		return nil
+	}
+	pull := func(rel string) error {
+		report.Downloaded = append(report.Downloaded, rel)
+		if cfg.dryRun {
+			return nil
+		}
+		data, err := c.ReadFileBytes(ctx, pathpkg.Join(remoteRoot, rel))
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(localDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, data, 0o644); err != nil {
+			return err
+		}
+		if mtime := remote[rel].ModifiedAt; !mtime.IsZero() {
+			os.Chtimes(dst, mtime, mtime)
+		}
+		newState[rel] = syncedFile{LocalHash: hashBytes(data), RemoteVersion: remoteVersion(remote[rel])}
+		return nil
+	}
+
+	// fail saves the progress made so far before returning err, keeping the
+	// old base for paths from the i'th on, which were not synced.
+	fail := func(i int, err error) (*SyncDirReport, error) {
+		if cfg.dryRun {
+			return report, err
+		}
+		for _, rel := range paths[i:] {
+			if base, ok := state[rel]; ok {
+				newState[rel] = base
+			}
+		}
+		if saveErr := saveSyncState(localDir, newState); saveErr != nil {
+			return report, errors.Join(err, saveErr)
+		}
+		return report, err
+	}
+
+	for i, rel := range paths {
+		if err := ctx.Err(); err != nil {
+			return fail(i, err)
+		}
+
+		l, hasLocal := local[rel]
+		r, hasRemote := remote[rel]
+		base, hasBase := state[rel]
+
+		if !hasLocal && !hasRemote {
+			continue
+		}
+
+		localChanged := !hasBase || !hasLocal || l.hash != base.LocalHash
+		remoteChanged := !hasBase || !hasRemote || remoteVersion(r) != base.RemoteVersion
+
+		var err error
+		switch {
+		case hasLocal && hasRemote && (!localChanged || l.hash == r.Checksum):
+			if remoteChanged && l.hash != r.Checksum {
+				err = pull(rel)
+			} else {
+				newState[rel] = syncedFile{LocalHash: l.hash, RemoteVersion: remoteVersion(r)}
+			}
+		case hasLocal && hasRemote && !remoteChanged:
+			err = push(rel)
+		case hasLocal && hasRemote:
+			resolution := conflictResolution(cfg.policy, l, r)
+			report.Conflicts = append(report.Conflicts, SyncConflict{Path: rel, Resolution: resolution})
+			switch resolution {
+			case "local":
+				err = push(rel)
+			case "remote":
+				err = pull(rel)
+			default:
+				// Keep the old base so the conflict is detected again next time.
+				if hasBase {
+					newState[rel] = base
+				}
+			}
+		case hasLocal && hasBase && !localChanged:
+			// Deleted remotely and unchanged locally.
+			report.DeletedLocal = append(report.DeletedLocal, rel)
+			if !cfg.dryRun {
+				err = os.Remove(filepath.Join(localDir, filepath.FromSlash(rel)))
+			}
+		case hasLocal:
+			err = push(rel)
+		case hasBase && !remoteChanged:
+			// Deleted locally and unchanged remotely.
+			report.DeletedRemote = append(report.DeletedRemote, rel)
+			if !cfg.dryRun {
+				err = c.DeleteFile(ctx, pathpkg.Join(remoteRoot, rel))
+			}
+		default:
+			err = pull(rel)
+		}
+		if err != nil {
+			return fail(i, fmt.Errorf("failed to sync %s: %w", rel, err))
+		}
+	}
+
+	if cfg.dryRun {
+		return report, nil
+	}
+	return report, saveSyncState(localDir, newState)
+}
+
+// conflictResolution returns which side wins a conflict: "local", "remote"
+// or "skipped".
+func conflictResolution(policy ConflictPolicy, l localFile, r FileInfo) string {
+	switch policy {
+	case ConflictPreferLocal:
+		return "local"
+	case ConflictPreferRemote:
+		return "remote"
+	case ConflictNewest:
+		if l.mtime.After(r.ModifiedAt) {
+			return "local"
+		}
+		return "remote"
+	}
+	return "skipped"
+}
+
+func scanLocalDir(dir string, cfg *syncDirConfig) (map[string]localFile, error) {
+	files := make(map[string]localFile)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == dir && os.IsNotExist(err) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !cfg.selected(rel) {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[rel] = localFile{hash: hashBytes(data), mtime: info.ModTime()}
+		return nil
+	})
+	return files, err
+}
+
+func (c *BridgeClient) scanRemoteDir(ctx context.Context, root string, cfg *syncDirConfig) (map[string]FileInfo, error) {
+	files := make(map[string]FileInfo)
+	prefix := strings.TrimSuffix(root, "/") + "/"
+	err := c.Walk(ctx, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel := strings.TrimPrefix(p, prefix)
+		if !cfg.selected(rel) {
+			return nil
+		}
+		info, _ := d.Info()
+		files[rel] = *info.Sys().(*FileInfo)
+		return nil
+	})
+	return files, err
+}
+
+// remoteVersion identifies the content of a remote file for change detection.
+func remoteVersion(info FileInfo) string {
+	if info.Checksum != "" {
+		return info.Checksum
+	}
+	return strconv.FormatInt(info.Size, 10) + "@" + info.ModifiedAt.UTC().Format(time.RFC3339Nano)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func loadSyncState(dir string) (map[string]syncedFile, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SyncStateFile))
+	if os.IsNotExist(err) {
+		return map[string]syncedFile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state map[string]syncedFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SyncStateFile, err)
+	}
+	return state, nil
+}
+
+func saveSyncState(dir string, state map[string]syncedFile) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, SyncStateFile), data, 0o644)
+}
@@ -0,0 +1,108 @@
+package bravozero
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// TailStream delivers the lines of a file opened with TailFile. It
+// implements Iterator[string].
+type TailStream struct {
+	lines  chan string
+	cancel context.CancelFunc
+	once   sync.Once
+
+	current string
+	err     error
+	readErr error
+}
+
+// TailFile streams the lines of a file: first its existing content, then, if
+// follow is true, each line appended afterwards until the stream is closed
+// or ctx is cancelled. Files under an encrypted prefix cannot be tailed.
+//
+//	stream, err := bridge.TailFile(ctx, "/logs/run.log", true)
+//	if err != nil { ... }
+//	defer stream.Close()
+//	for stream.Next(ctx) {
+//		fmt.Println(stream.Value())
+//	}
+func (c *BridgeClient) TailFile(ctx context.Context, path string, follow bool) (*TailStream, error) {
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return nil, fmt.Errorf("cannot tail encrypted path %s", path)
+	}
+
+	params := url.Values{}
+	params.Set("path", path)
+	if follow {
+		params.Set("follow", "true")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	resp, _, err := c.openEventStream(ctx, "/file/tail?"+params.Encode(), "")
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := &TailStream{
+		lines:  make(chan string),
+		cancel: cancel,
+	}
+	go func() {
+		defer close(s.lines)
+		defer resp.Body.Close()
+		err := readSSE(resp.Body, func(e sseEvent) error {
+			select {
+			case s.lines <- e.Data:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if ctx.Err() == nil {
+			s.readErr = err
+		}
+	}()
+
+	return s, nil
+}
+
+// Next waits for the next line. It returns false when the stream ends, is
+// closed, or ctx is done.
+func (s *TailStream) Next(ctx context.Context) bool {
+	if s.err != nil {
+		return false
+	}
+	select {
+	case line, ok := <-s.lines:
+		if !ok {
+			// readErr is written before lines is closed.
+			s.err = s.readErr
+			return false
+		}
+		s.current = line
+		return true
+	case <-ctx.Done():
+		s.err = ctx.Err()
+		return false
+	}
+}
+
+// Value returns the current line, without its trailing newline.
+func (s *TailStream) Value() string {
+	return s.current
+}
+
+// Err returns the error that ended the stream, if any.
+func (s *TailStream) Err() error {
+	return s.err
+}
+
+// Close stops the stream.
+func (s *TailStream) Close() error {
+	s.once.Do(s.cancel)
+	return nil
+}
@@ -0,0 +1,45 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TransferTo copies the file or directory at path into another agent's
+// workspace at targetPath, server-side. The transfer is evaluated by the
+// Constitution Agent; if it is denied a *ConstitutionDeniedError is returned.
+// The returned FileInfo describes the copy in the target workspace. Files
+// under an encrypted prefix cannot be transferred, since the target agent
+// would receive ciphertext it has no key for.
+func (c *BridgeClient) TransferTo(ctx context.Context, path, targetAgentID, targetPath string) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.transfer", map[string]interface{}{"path": path, "targetAgentId": targetAgentID, "targetPath": targetPath})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return nil, fmt.Errorf("cannot transfer encrypted path %s", path)
+	}
+
+	body := map[string]string{
+		"path":          path,
+		"targetAgentId": targetAgentID,
+		"targetPath":    targetPath,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/transfer", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// The path is in the target's namespace; don't map it through our
+	// encrypted prefixes.
+	return data.toFileInfo(), nil
+}
@@ -0,0 +1,105 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type deleteConfig struct {
+	soft bool
+}
+
+// DeleteOption configures DeleteFile.
+type DeleteOption func(*deleteConfig)
+
+// WithSoftDelete moves the file to the trash instead of deleting it. Trashed
+// files are kept for the server's retention window and can be brought back
+// with Restore.
+func WithSoftDelete() DeleteOption {
+	return func(c *deleteConfig) {
+		c.soft = true
+	}
+}
+
+// TrashEntry is a soft-deleted file or directory.
+type TrashEntry struct {
+	Path        string    `json:"path"`
+	Size        int64     `json:"size"`
+	IsDirectory bool      `json:"isDirectory"`
+	DeletedBy   string    `json:"deletedBy"`
+	DeletedAt   time.Time `json:"deletedAt"`
+	// ExpiresAt is when the entry will be permanently deleted.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ListTrash lists soft-deleted files that can still be restored, most
+// recently deleted first.
+func (c *BridgeClient) ListTrash(ctx context.Context) ([]TrashEntry, error) {
+	resp, err := c.doRequest(ctx, "GET", "/trash", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Entries []struct {
+			Path        string `json:"path"`
+			Size        int64  `json:"size"`
+			IsDirectory bool   `json:"isDirectory"`
+			DeletedBy   string `json:"deletedBy"`
+			DeletedAt   string `json:"deletedAt"`
+			ExpiresAt   string `json:"expiresAt"`
+		} `json:"entries"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	entries := make([]TrashEntry, len(data.Entries))
+	for i, e := range data.Entries {
+		deletedAt, _ := time.Parse(time.RFC3339, e.DeletedAt)
+		expiresAt, _ := time.Parse(time.RFC3339, e.ExpiresAt)
+		entries[i] = TrashEntry{
+			Path:        c.localPath(ctx, e.Path),
+			Size:        e.Size,
+			IsDirectory: e.IsDirectory,
+			DeletedBy:   e.DeletedBy,
+			DeletedAt:   deletedAt,
+			ExpiresAt:   expiresAt,
+		}
+	}
+
+	return entries, nil
+}
+
+// Restore moves a soft-deleted file back to its original path. It fails if
+// a file has since been created at that path.
+func (c *BridgeClient) Restore(ctx context.Context, path string) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.restore", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{"path": remote}
+
+	resp, err := c.doRequest(ctx, "POST", "/trash/restore", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
@@ -0,0 +1,179 @@
+package bravozero
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// UploadSession is an in-progress multipart upload started by StartUpload.
+type UploadSession struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+	// PartSize is the server's preferred part size in bytes. Every part but
+	// the last must be at least this large.
+	PartSize  int64     `json:"partSize"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// UploadedPart identifies a part accepted by UploadPart.
+type UploadedPart struct {
+	PartNumber int    `json:"partNumber"`
+	Size       int64  `json:"size"`
+	Checksum   string `json:"checksum"`
+}
+
+// StartUpload begins a multipart upload to path. Upload the parts with
+// UploadPart, then call CompleteUpload to assemble them, or AbortUpload to
+// discard them. Sessions survive client restarts until ExpiresAt, so an
+// interrupted upload can be resumed by checking ListUploadedParts and
+// sending only the missing parts.
+//
+// Multipart uploads are not supported under encrypted prefixes.
+func (c *BridgeClient) StartUpload(ctx context.Context, path string, createDirs bool) (*UploadSession, error) {
+	ctx, err := c.guard.check(ctx, "bridge.write", map[string]interface{}{"path": path})
+	if err != nil {
+		return nil, err
+	}
+	return c.startUpload(ctx, path, createDirs)
+}
+
+func (c *BridgeClient) startUpload(ctx context.Context, path string, createDirs bool) (*UploadSession, error) {
+	if _, ok := c.encryption.encryptedPrefix(path); ok {
+		return nil, fmt.Errorf("multipart upload is not supported for encrypted path %s", path)
+	}
+
+	body := map[string]interface{}{
+		"path":       path,
+		"createDirs": createDirs,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/uploads", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		ID        string `json:"id"`
+		Path      string `json:"path"`
+		PartSize  int64  `json:"partSize"`
+		ExpiresAt string `json:"expiresAt"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	expiresAt, _ := time.Parse(time.RFC3339, data.ExpiresAt)
+
+	return &UploadSession{
+		ID:        data.ID,
+		Path:      data.Path,
+		PartSize:  data.PartSize,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// UploadPart uploads one part of a multipart upload. Part numbers start at 1
+// and determine the order parts are assembled in; re-uploading a part number
+// replaces it.
+func (c *BridgeClient) UploadPart(ctx context.Context, uploadID string, partNumber int, data []byte) (*UploadedPart, error) {
+	ctx, err := c.guard.check(ctx, "bridge.upload_part", map[string]interface{}{"uploadId": uploadID, "partNumber": partNumber})
+	if err != nil {
+		return nil, err
+	}
+	return c.uploadPart(ctx, uploadID, partNumber, data)
+}
+
+func (c *BridgeClient) uploadPart(ctx context.Context, uploadID string, partNumber int, data []byte) (*UploadedPart, error) {
+	if partNumber < 1 {
+		return nil, fmt.Errorf("part number must be at least 1, got %d", partNumber)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	body := map[string]interface{}{
+		"data":     data,
+		"checksum": checksum,
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/uploads/%s/parts/%d", url.PathEscape(uploadID), partNumber), body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var part UploadedPart
+	if err := json.NewDecoder(resp.Body).Decode(&part); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if part.Checksum != "" && part.Checksum != checksum {
+		return nil, fmt.Errorf("part %d checksum mismatch: sent %s, server stored %s", partNumber, checksum, part.Checksum)
+	}
+
+	return &part, nil
+}
+
+// ListUploadedParts lists the parts received so far for an upload, ordered
+// by part number.
+func (c *BridgeClient) ListUploadedParts(ctx context.Context, uploadID string) ([]UploadedPart, error) {
+	resp, err := c.doRequest(ctx, "GET", "/uploads/"+url.PathEscape(uploadID)+"/parts", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Parts []UploadedPart `json:"parts"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.Parts, nil
+}
+
+// CompleteUpload assembles the given parts, in part number order, into the
+// upload's file and ends the session.
+func (c *BridgeClient) CompleteUpload(ctx context.Context, uploadID string, parts []UploadedPart) (*FileInfo, error) {
+	ctx, err := c.guard.check(ctx, "bridge.complete_upload", map[string]interface{}{"uploadId": uploadID, "parts": len(parts)})
+	if err != nil {
+		return nil, err
+	}
+	return c.completeUpload(ctx, uploadID, parts)
+}
+
+func (c *BridgeClient) completeUpload(ctx context.Context, uploadID string, parts []UploadedPart) (*FileInfo, error) {
+	body := map[string]interface{}{"parts": parts}
+
+	resp, err := c.doRequest(ctx, "POST", "/uploads/"+url.PathEscape(uploadID)+"/complete", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawFileInfo
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return c.fileInfo(ctx, data), nil
+}
+
+// AbortUpload discards an upload session and any parts already uploaded.
+func (c *BridgeClient) AbortUpload(ctx context.Context, uploadID string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/uploads/"+url.PathEscape(uploadID), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
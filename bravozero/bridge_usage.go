@@ -0,0 +1,61 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// DirUsage summarizes the disk usage of a VFS subtree.
+type DirUsage struct {
+	Path       string `json:"path"`
+	TotalBytes int64  `json:"totalBytes"`
+	FileCount  int    `json:"fileCount"`
+	DirCount   int    `json:"dirCount"`
+	// LargestFiles lists the biggest files in the subtree, largest first.
+	LargestFiles []FileInfo `json:"largestFiles"`
+}
+
+// DirSize computes the total size of the subtree at path server-side,
+// without listing it on the client.
+func (c *BridgeClient) DirSize(ctx context.Context, path string) (*DirUsage, error) {
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+
+	resp, err := c.doRequest(ctx, "GET", "/usage?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Path         string        `json:"path"`
+		TotalBytes   int64         `json:"totalBytes"`
+		FileCount    int           `json:"fileCount"`
+		DirCount     int           `json:"dirCount"`
+		LargestFiles []rawFileInfo `json:"largestFiles"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	largest := make([]FileInfo, len(data.LargestFiles))
+	for i, f := range data.LargestFiles {
+		largest[i] = *c.fileInfo(ctx, f)
+	}
+
+	return &DirUsage{
+		Path:         c.localPath(ctx, data.Path),
+		TotalBytes:   data.TotalBytes,
+		FileCount:    data.FileCount,
+		DirCount:     data.DirCount,
+		LargestFiles: largest,
+	}, nil
+}
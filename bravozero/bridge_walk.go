@@ -0,0 +1,105 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	pathpkg "path"
+	"strconv"
+)
+
+// defaultWalkPageSize is the listing page size used by Walk.
+const defaultWalkPageSize = 500
+
+// IterFiles returns an iterator over the direct children of the directory at
+// path, fetching pageSize entries per request. A pageSize of 0 lets the
+// server choose.
+func (c *BridgeClient) IterFiles(path string, pageSize int) *PageIterator[FileInfo] {
+	return NewPageIterator(func(ctx context.Context, pageToken string) ([]FileInfo, string, error) {
+		remote, err := c.remotePath(ctx, path)
+		if err != nil {
+			return nil, "", err
+		}
+
+		params := url.Values{}
+		params.Set("path", remote)
+		if pageSize > 0 {
+			params.Set("limit", strconv.Itoa(pageSize))
+		}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+
+		resp, err := c.doRequest(ctx, "GET", "/files?"+params.Encode(), nil)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+
+		var data struct {
+			Files         []rawFileInfo `json:"files"`
+			NextPageToken string        `json:"nextPageToken"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			return nil, "", fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		files := make([]FileInfo, len(data.Files))
+		for i, f := range data.Files {
+			files[i] = *c.fileInfo(ctx, f)
+		}
+		return files, data.NextPageToken, nil
+	})
+}
+
+// Walk walks the VFS tree rooted at root, calling fn for each file and
+// directory, including root, with the same semantics as fs.WalkDir: returning
+// fs.SkipDir skips a directory and fs.SkipAll stops the walk. Directory
+// listings are paged lazily, so entries are visited in server order and huge
+// trees are never held in memory at once.
+func (c *BridgeClient) Walk(ctx context.Context, root string, fn fs.WalkDirFunc) error {
+	rootEntry := fs.FileInfoToDirEntry(bridgeFileInfo{FileInfo{
+		Path:        root,
+		Name:        pathpkg.Base(root),
+		IsDirectory: true,
+	}})
+
+	err := fn(root, rootEntry, nil)
+	if err == nil {
+		err = c.walkDir(ctx, root, rootEntry, fn)
+	}
+	if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func (c *BridgeClient) walkDir(ctx context.Context, dir string, d fs.DirEntry, fn fs.WalkDirFunc) error {
+	it := c.IterFiles(dir, defaultWalkPageSize)
+	for it.Next(ctx) {
+		info := it.Value()
+		entry := fs.FileInfoToDirEntry(bridgeFileInfo{info})
+
+		err := fn(info.Path, entry, nil)
+		if err == nil && info.IsDirectory {
+			err = c.walkDir(ctx, info.Path, entry, fn)
+		}
+		if err != nil {
+			if errors.Is(err, fs.SkipDir) && info.IsDirectory {
+				continue
+			}
+			return err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		if err := fn(dir, d, err); err != nil && !errors.Is(err, fs.SkipDir) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// FileEventType is the kind of change reported by Watch.
+type FileEventType string
+
+const (
+	FileCreated  FileEventType = "create"
+	FileModified FileEventType = "modify"
+	FileDeleted  FileEventType = "delete"
+)
+
+// FileEvent describes a change to a file in the VFS.
+type FileEvent struct {
+	Type        FileEventType `json:"type"`
+	Path        string        `json:"path"`
+	IsDirectory bool          `json:"isDirectory"`
+	AgentID     string        `json:"agentId"`
+	Timestamp   time.Time     `json:"timestamp"`
+}
+
+// WatchHandler is called for each file event. Handlers are called serially.
+type WatchHandler func(FileEvent)
+
+type watchConfig struct {
+	patterns []string
+	events   map[FileEventType]bool
+	debounce time.Duration
+
+	reconnect   bool
+	maxAttempts int
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchConfig)
+
+// WithWatchPatterns only delivers events whose path or base name matches one
+// of the glob patterns (path.Match syntax).
+func WithWatchPatterns(patterns ...string) WatchOption {
+	return func(c *watchConfig) {
+		c.patterns = append(c.patterns, patterns...)
+	}
+}
+
+// WithWatchEvents only delivers events of the given types.
+func WithWatchEvents(types ...FileEventType) WatchOption {
+	return func(c *watchConfig) {
+		if c.events == nil {
+			c.events = make(map[FileEventType]bool)
+		}
+		for _, t := range types {
+			c.events[t] = true
+		}
+	}
+}
+
+// WithDebounce coalesces events for the same path that arrive within d of
+// each other into a single event, delivered once the path has been quiet for d.
+func WithDebounce(d time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		c.debounce = d
+	}
+}
+
+func (w *watchConfig) matches(ev FileEvent) bool {
+	if w.events != nil && !w.events[ev.Type] {
+		return false
+	}
+	if len(w.patterns) == 0 {
+		return true
+	}
+	for _, p := range w.patterns {
+		if ok, _ := path.Match(p, ev.Path); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, path.Base(ev.Path)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WithReconnect makes Watch reconnect when the stream drops, resuming from
+// the last event received via Last-Event-ID so no events are missed.
+// Reconnects back off exponentially up to 30s; Watch gives up after
+// maxAttempts consecutive failures, or never if maxAttempts is 0.
+func WithReconnect(maxAttempts int) WatchOption {
+	return func(c *watchConfig) {
+		c.reconnect = true
+		c.maxAttempts = maxAttempts
+	}
+}
+
+// Watch subscribes to file changes under path and calls handler for each event
+// until ctx is cancelled or the stream ends. It returns ctx.Err() on cancellation.
+//
+// Watch filters and debounces events client-side; see WithWatchPatterns,
+// WithWatchEvents and WithDebounce. Use WithReconnect to survive dropped
+// connections.
+func (c *BridgeClient) Watch(ctx context.Context, path string, recursive bool, handler WatchHandler, opts ...WatchOption) error {
+	cfg := &watchConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	remote, err := c.remotePath(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("path", remote)
+	if recursive {
+		params.Set("recursive", "true")
+	}
+
+	events := make(chan FileEvent)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(events)
+		readErr <- c.streamWatch(ctx, params, cfg, events)
+	}()
+
+	dispatchEvents(ctx, events, cfg.debounce, handler)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return <-readErr
+}
+
+// streamWatch reads the watch stream into events, reconnecting if configured.
+func (c *BridgeClient) streamWatch(ctx context.Context, params url.Values, cfg *watchConfig, events chan<- FileEvent) error {
+	var lastEventID string
+	failures := 0
+
+	for {
+		resp, retryable, err := c.openEventStream(ctx, "/watch?"+params.Encode(), lastEventID)
+		if err == nil {
+			failures = 0
+			err = readSSE(resp.Body, func(e sseEvent) error {
+				var ev FileEvent
+				if err := json.Unmarshal([]byte(e.Data), &ev); err != nil {
+					retryable = false
+					return fmt.Errorf("failed to decode event: %w", err)
+				}
+				if e.ID != "" {
+					lastEventID = e.ID
+				}
+				ev.Path = c.localPath(ctx, ev.Path)
+				if !cfg.matches(ev) {
+					return nil
+				}
+				select {
+				case events <- ev:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+			resp.Body.Close()
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !cfg.reconnect || !retryable {
+			return err
+		}
+
+		failures++
+		if cfg.maxAttempts > 0 && failures > cfg.maxAttempts {
+			return err
+		}
+
+		backoff := 30 * time.Second
+		if failures < 6 {
+			backoff = time.Second << (failures - 1)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// openEventStream opens a server-sent event stream. retryable reports whether
+// a failure, or the stream later ending, is worth reconnecting after.
+func (c *BridgeClient) openEventStream(ctx context.Context, path, lastEventID string) (resp *http.Response, retryable bool, err error) {
+	req, err := newSSERequest(ctx, c.baseURL+path, c.apiKey, c.agentID, c.authenticator, lastEventID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp, retryable, err = openSSE(c.streamClient(), req)
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 404 {
+		return nil, false, c.notFound(ctx, req)
+	}
+	return resp, retryable, err
+}
+
+// dispatchEvents delivers events to handler, coalescing per-path bursts when
+// debounce is positive. It returns when events is closed or ctx is done.
+func dispatchEvents(ctx context.Context, events <-chan FileEvent, debounce time.Duration, handler WatchHandler) {
+	if debounce <= 0 {
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				handler(ev)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+
+	pending := make(map[string]FileEvent)
+	deadlines := make(map[string]time.Time)
+	tick := debounce / 4
+	if tick <= 0 {
+		tick = debounce
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	flush := func(now time.Time, all bool) {
+		for p, ev := range pending {
+			if all || !now.Before(deadlines[p]) {
+				delete(pending, p)
+				delete(deadlines, p)
+				handler(ev)
+			}
+		}
+	}
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				flush(time.Now(), true)
+				return
+			}
+			if prev, exists := pending[ev.Path]; exists {
+				ev = coalesceEvents(prev, ev)
+			}
+			pending[ev.Path] = ev
+			deadlines[ev.Path] = time.Now().Add(debounce)
+		case now := <-ticker.C:
+			flush(now, false)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// coalesceEvents merges two events for the same path. A create followed by
+// modifications is still a create; anything followed by a delete is a delete.
+func coalesceEvents(prev, next FileEvent) FileEvent {
+	if prev.Type == FileCreated && next.Type == FileModified {
+		next.Type = FileCreated
+	}
+	return next
+}
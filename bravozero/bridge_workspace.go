@@ -0,0 +1,499 @@
+package bravozero
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Workspace is a VFS subtree checked out to a local directory. Edit the files
+// under Dir with ordinary file APIs or tools, then call Push to write the
+// changes back. Files changed remotely in the meantime are three-way merged;
+// overlapping edits are left in the local file with conflict markers and
+// reported, to be resolved and pushed again.
+//
+// A Workspace keeps the checked-out content of every file in memory as the
+// merge base, so it is suited to source trees rather than bulk data.
+type Workspace struct {
+	client     *BridgeClient
+	remoteRoot string
+	dir        string
+	temp       bool
+	base       map[string]workspaceBase
+	// conflicted holds the files Push wrote conflict markers into.
+	conflicted map[string]bool
+}
+
+type workspaceBase struct {
+	checksum string
+	content  []byte
+}
+
+// WorkspaceStatus lists local changes relative to the last checkout or push,
+// as slash-separated paths relative to the workspace root.
+type WorkspaceStatus struct {
+	Modified []string
+	Added    []string
+	Deleted  []string
+}
+
+// Clean reports whether there are no local changes.
+func (s *WorkspaceStatus) Clean() bool {
+	return len(s.Modified) == 0 && len(s.Added) == 0 && len(s.Deleted) == 0
+}
+
+// WorkspaceConflict is a file Push could not write back.
+type WorkspaceConflict struct {
+	Path   string
+	Reason string
+}
+
+// PushResult reports what Push did.
+type PushResult struct {
+	// Pushed files were written back unchanged from the local copy.
+	Pushed []string
+	// Merged files had remote changes that were merged cleanly with the
+	// local changes before being written back; the local copy is updated.
+	Merged  []string
+	Deleted []string
+	// Conflicts were not written back. For overlapping edits, the local
+	// file now contains conflict markers.
+	Conflicts []WorkspaceConflict
+}
+
+// Checkout downloads the subtree at remotePath into dir and returns a
+// Workspace tracking it. If dir is empty, a temporary directory is created
+// and removed by Close.
+func (c *BridgeClient) Checkout(ctx context.Context, remotePath, dir string) (*Workspace, error) {
+	w := &Workspace{
+		client:     c,
+		remoteRoot: pathpkg.Clean("/" + remotePath),
+		dir:        dir,
+		base:       make(map[string]workspaceBase),
+		conflicted: make(map[string]bool),
+	}
+
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "bravozero-workspace-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create workspace directory: %w", err)
+		}
+		w.dir = tmp
+		w.temp = true
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create workspace directory: %w", err)
+	}
+
+	prefix := strings.TrimSuffix(w.remoteRoot, "/") + "/"
+	err := c.Walk(ctx, w.remoteRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel := strings.TrimPrefix(p, prefix)
+		info, _ := d.Info()
+
+		data, err := c.ReadFileBytes(ctx, p)
+		if err != nil {
+			return err
+		}
+		if err := w.writeLocal(rel, data); err != nil {
+			return err
+		}
+		w.base[rel] = workspaceBase{checksum: info.Sys().(*FileInfo).Checksum, content: data}
+		return nil
+	})
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Dir returns the local directory holding the checked-out files.
+func (w *Workspace) Dir() string {
+	return w.dir
+}
+
+// Close removes the local directory if Checkout created it.
+func (w *Workspace) Close() error {
+	if !w.temp {
+		return nil
+	}
+	return os.RemoveAll(w.dir)
+}
+
+// Status compares the local files with the last checked-out or pushed state.
+func (w *Workspace) Status() (*WorkspaceStatus, error) {
+	local, err := scanLocalDir(w.dir, &syncDirConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	status := &WorkspaceStatus{}
+	for rel, f := range local {
+		base, ok := w.base[rel]
+		switch {
+		case !ok:
+			status.Added = append(status.Added, rel)
+		case f.hash != hashBytes(base.content):
+			status.Modified = append(status.Modified, rel)
+		}
+	}
+	for rel := range w.base {
+		if _, ok := local[rel]; !ok {
+			status.Deleted = append(status.Deleted, rel)
+		}
+	}
+
+	sort.Strings(status.Modified)
+	sort.Strings(status.Added)
+	sort.Strings(status.Deleted)
+	return status, nil
+}
+
+// Push writes local changes back to the VFS. Each write is conditional on the
+// remote file being unchanged since checkout; if it has changed, the remote
+// content is merged with the local changes. Push continues past conflicts
+// and returns an error only if a request fails. A file left with conflict
+// markers by an earlier Push is not written back until they are removed.
+func (w *Workspace) Push(ctx context.Context) (*PushResult, error) {
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PushResult{}
+	for _, rel := range append(status.Modified, status.Added...) {
+		if err := w.pushFile(ctx, rel, result); err != nil {
+			return result, fmt.Errorf("failed to push %s: %w", rel, err)
+		}
+	}
+	for _, rel := range status.Deleted {
+		if err := w.pushDelete(ctx, rel, result); err != nil {
+			return result, fmt.Errorf("failed to delete %s: %w", rel, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (w *Workspace) pushFile(ctx context.Context, rel string, result *PushResult) error {
+	local, err := os.ReadFile(w.localPath(rel))
+	if err != nil {
+		return err
+	}
+	remote := pathpkg.Join(w.remoteRoot, rel)
+
+	if w.conflicted[rel] {
+		if hasConflictMarkers(local) {
+			result.Conflicts = append(result.Conflicts, WorkspaceConflict{Path: rel, Reason: "unresolved conflict markers"})
+			return nil
+		}
+		delete(w.conflicted, rel)
+	}
+
+	base, hasBase := w.base[rel]
+	var opts []WriteOption
+	switch {
+	case hasBase && base.checksum != "":
+		opts = append(opts, WithIfMatch(base.checksum))
+	case hasBase:
+		// Without a checksum the write cannot be made conditional, so compare
+		// the remote content with the merge base instead.
+		theirs, err := w.client.ReadFileBytes(ctx, remote)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			opts = append(opts, WithIfNoneMatch("*"))
+		case err != nil:
+			return err
+		case !bytes.Equal(theirs, base.content):
+			return w.mergeFile(ctx, rel, local, base.content, theirs, "", result)
+		}
+	default:
+		// Added locally: only create the file if nobody else has.
+		opts = append(opts, WithIfNoneMatch("*"))
+	}
+
+	info, err := w.client.WriteFileBytes(ctx, remote, local, true, opts...)
+	if err == nil {
+		w.base[rel] = workspaceBase{checksum: info.Checksum, content: local}
+		result.Pushed = append(result.Pushed, rel)
+		return nil
+	}
+
+	var precondFailed *PreconditionFailedError
+	if !errors.As(err, &precondFailed) {
+		return err
+	}
+
+	// The remote file changed since checkout, or was created concurrently
+	// with a local add: merge.
+	theirs, err := w.client.ReadFileBytes(ctx, remote)
+	if err != nil {
+		return err
+	}
+	return w.mergeFile(ctx, rel, local, base.content, theirs, precondFailed.CurrentChecksum, result)
+}
+
+// mergeFile merges local and remote changes to rel against base and writes
+// the result back, conditional on the remote checksum still being current.
+func (w *Workspace) mergeFile(ctx context.Context, rel string, local, base, theirs []byte, current string, result *PushResult) error {
+	remote := pathpkg.Join(w.remoteRoot, rel)
+
+	if bytes.Equal(local, theirs) {
+		// Both sides made the same change.
+		w.base[rel] = workspaceBase{checksum: current, content: theirs}
+		result.Merged = append(result.Merged, rel)
+		return nil
+	}
+	if bytes.IndexByte(local, 0) >= 0 || bytes.IndexByte(theirs, 0) >= 0 {
+		result.Conflicts = append(result.Conflicts, WorkspaceConflict{Path: rel, Reason: "binary file changed on both sides"})
+		return nil
+	}
+
+	merged, conflicted, ok := merge3(base, local, theirs)
+	if !ok {
+		result.Conflicts = append(result.Conflicts, WorkspaceConflict{Path: rel, Reason: "file changed on both sides and is too large to merge"})
+		return nil
+	}
+	// Whatever happens next, the remote content is the new merge base.
+	w.base[rel] = workspaceBase{checksum: current, content: theirs}
+
+	if conflicted {
+		if err := w.writeLocal(rel, merged); err != nil {
+			return err
+		}
+		w.conflicted[rel] = true
+		result.Conflicts = append(result.Conflicts, WorkspaceConflict{Path: rel, Reason: "overlapping changes"})
+		return nil
+	}
+
+	opts := []WriteOption{}
+	if current != "" {
+		opts = append(opts, WithIfMatch(current))
+	}
+	info, err := w.client.WriteFileBytes(ctx, remote, merged, true, opts...)
+	if err != nil {
+		var precondFailed *PreconditionFailedError
+		if errors.As(err, &precondFailed) {
+			result.Conflicts = append(result.Conflicts, WorkspaceConflict{Path: rel, Reason: "changed again during merge"})
+			return nil
+		}
+		return err
+	}
+	if err := w.writeLocal(rel, merged); err != nil {
+		return err
+	}
+	w.base[rel] = workspaceBase{checksum: info.Checksum, content: merged}
+	result.Merged = append(result.Merged, rel)
+	return nil
+}
+
+func (w *Workspace) pushDelete(ctx context.Context, rel string, result *PushResult) error {
+	remote := pathpkg.Join(w.remoteRoot, rel)
+	base := w.base[rel]
+
+	listing, err := w.client.ListFiles(ctx, pathpkg.Dir(remote), false, "")
+	if err != nil {
+		return err
+	}
+	for _, f := range listing.Files {
+		if f.Path != remote {
+			continue
+		}
+		if base.checksum != "" && f.Checksum != base.checksum {
+			result.Conflicts = append(result.Conflicts, WorkspaceConflict{Path: rel, Reason: "deleted locally but changed remotely"})
+			return nil
+		}
+		if err := w.client.DeleteFile(ctx, remote); err != nil {
+			return err
+		}
+		break
+	}
+
+	delete(w.base, rel)
+	delete(w.conflicted, rel)
+	result.Deleted = append(result.Deleted, rel)
+	return nil
+}
+
+func (w *Workspace) localPath(rel string) string {
+	return filepath.Join(w.dir, filepath.FromSlash(rel))
+}
+
+func (w *Workspace) writeLocal(rel string, data []byte) error {
+	dst := w.localPath(rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+// Conflict markers written by merge3, one per line.
+const (
+	conflictStart = "<<<<<<< local\n"
+	conflictSep   = "=======\n"
+	conflictEnd   = ">>>>>>> remote\n"
+)
+
+// hasConflictMarkers reports whether data still contains a line merge3 uses
+// to open or close a conflict section.
+func hasConflictMarkers(data []byte) bool {
+	for _, line := range splitLines(data) {
+		if line == conflictStart || line == conflictEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// merge3 performs a line-based three-way merge of ours and theirs against
+// base. Overlapping changes are emitted between conflict markers and
+// conflicted is set. ok is false if the inputs are too large to diff.
+func merge3(base, ours, theirs []byte) (merged []byte, conflicted, ok bool) {
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	matchO, okO := lcsMatch(b, o)
+	matchT, okT := lcsMatch(b, t)
+	if !okO || !okT {
+		return nil, false, false
+	}
+
+	var out []string
+	emit := func(bc, oc, tc []string) {
+		switch {
+		case equalLines(oc, bc):
+			out = append(out, tc...)
+		case equalLines(tc, bc), equalLines(oc, tc):
+			out = append(out, oc...)
+		default:
+			conflicted = true
+			out = append(out, conflictStart)
+			out = append(out, terminate(oc)...)
+			out = append(out, conflictSep)
+			out = append(out, terminate(tc)...)
+			out = append(out, conflictEnd)
+		}
+	}
+
+	i, oi, ti := 0, 0, 0
+	for j := range b {
+		oj, tj := matchO[j], matchT[j]
+		if oj < 0 || tj < 0 {
+			continue
+		}
+		// base[j] is unchanged on both sides: everything before it is a chunk.
+		emit(b[i:j], o[oi:oj], t[ti:tj])
+		out = append(out, b[j])
+		i, oi, ti = j+1, oj+1, tj+1
+	}
+	emit(b[i:], o[oi:], t[ti:])
+
+	return []byte(strings.Join(out, "")), conflicted, true
+}
+
+// splitLines splits data into lines, each keeping its trailing newline.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// terminate makes sure the last line of a conflict section ends in a newline
+// so the following marker starts on its own line.
+func terminate(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines = append(lines[:n-1:n-1], lines[n-1]+"\n")
+	}
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// maxMergeCells bounds the size of the table lcsMatch builds for the lines
+// that differ between its inputs, about 16MB.
+const maxMergeCells = 4 << 20
+
+// lcsMatch returns, for each line of a, the index of the line of b it is
+// matched to in a longest common subsequence, or -1. Common leading and
+// trailing lines are matched directly; ok is false if what remains would
+// need a table larger than maxMergeCells.
+func lcsMatch(a, b []string) (match []int, ok bool) {
+	match = make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		match[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(a), len(b)
+	if int64(n+1)*int64(m+1) > maxMergeCells {
+		return nil, false
+	}
+
+	lengths := make([][]int32, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			match[prefix+i] = prefix + j
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match, true
+}
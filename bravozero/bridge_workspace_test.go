@@ -0,0 +1,153 @@
+package bravozero
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	tests := []struct {
+		name           string
+		base           string
+		ours           string
+		theirs         string
+		want           string
+		wantConflicted bool
+	}{
+		{
+			name:   "both sides unchanged",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nb\nc\n",
+		},
+		{
+			name:   "local change only",
+			base:   "a\nb\nc\n",
+			ours:   "a\nB\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nB\nc\n",
+		},
+		{
+			name:   "remote change only",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nb\nC\n",
+			want:   "a\nb\nC\n",
+		},
+		{
+			name:   "separate changes on both sides",
+			base:   "a\nb\nc\nd\ne\n",
+			ours:   "A\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\nE\n",
+			want:   "A\nb\nc\nd\nE\n",
+		},
+		{
+			name:   "same change on both sides",
+			base:   "a\nb\nc\n",
+			ours:   "a\nX\nc\n",
+			theirs: "a\nX\nc\n",
+			want:   "a\nX\nc\n",
+		},
+		{
+			name:           "overlapping edits",
+			base:           "a\nb\nc\n",
+			ours:           "a\nlocal\nc\n",
+			theirs:         "a\nremote\nc\n",
+			want:           "a\n<<<<<<< local\nlocal\n=======\nremote\n>>>>>>> remote\nc\n",
+			wantConflicted: true,
+		},
+		{
+			name:   "no trailing newline",
+			base:   "a\nb\nc",
+			ours:   "A\nb\nc",
+			theirs: "a\nb\nC",
+			want:   "A\nb\nC",
+		},
+		{
+			name:           "overlapping edits without trailing newline",
+			base:           "a\nb",
+			ours:           "a\nlocal",
+			theirs:         "a\nremote",
+			want:           "a\n<<<<<<< local\nlocal\n=======\nremote\n>>>>>>> remote\n",
+			wantConflicted: true,
+		},
+		{
+			name:   "empty base",
+			base:   "",
+			ours:   "",
+			theirs: "new\n",
+			want:   "new\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicted, ok := merge3([]byte(tt.base), []byte(tt.ours), []byte(tt.theirs))
+			if !ok {
+				t.Fatal("merge3 reported the inputs as too large")
+			}
+			if string(merged) != tt.want {
+				t.Errorf("merged = %q, want %q", merged, tt.want)
+			}
+			if conflicted != tt.wantConflicted {
+				t.Errorf("conflicted = %v, want %v", conflicted, tt.wantConflicted)
+			}
+		})
+	}
+}
+
+func TestMerge3TooLarge(t *testing.T) {
+	// Every line differs, so nothing is matched as a common prefix or suffix
+	// and the table needs more than maxMergeCells cells.
+	var base, ours strings.Builder
+	for i := 0; i < 2100; i++ {
+		fmt.Fprintf(&base, "base %d\n", i)
+		fmt.Fprintf(&ours, "ours %d\n", i)
+	}
+
+	if _, _, ok := merge3([]byte(base.String()), []byte(ours.String()), []byte(base.String())); ok {
+		t.Error("merge3 succeeded on inputs over maxMergeCells")
+	}
+}
+
+func TestLCSMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []int
+	}{
+		{"equal", []string{"a", "b"}, []string{"a", "b"}, []int{0, 1}},
+		{"inserted line", []string{"a", "c"}, []string{"a", "b", "c"}, []int{0, 2}},
+		{"deleted line", []string{"a", "b", "c"}, []string{"a", "c"}, []int{0, -1, 1}},
+		{"no common lines", []string{"a"}, []string{"b"}, []int{-1}},
+		{"empty b", []string{"a"}, nil, []int{-1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lcsMatch(tt.a, tt.b)
+			if !ok {
+				t.Fatal("lcsMatch reported the inputs as too large")
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("lcsMatch = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	merged, _, _ := merge3([]byte("a\n"), []byte("b\n"), []byte("c\n"))
+	if !hasConflictMarkers(merged) {
+		t.Errorf("hasConflictMarkers(%q) = false, want true", merged)
+	}
+
+	resolved := "b\nc\n"
+	if hasConflictMarkers([]byte(resolved)) {
+		t.Errorf("hasConflictMarkers(%q) = true, want false", resolved)
+	}
+	// A separator alone, e.g. a Markdown heading underline, is not a marker.
+	if hasConflictMarkers([]byte("Title\n=======\n")) {
+		t.Error("hasConflictMarkers treated a lone separator line as a marker")
+	}
+}
@@ -0,0 +1,435 @@
+// Package bridgefuse adapts the Forge Bridge VFS to the operations a FUSE
+// filesystem implements, so the agent workspace can be mounted as a local
+// directory for tools that know nothing about the SDK.
+//
+// To mount the VFS, use Mount from the fusemount module in this directory:
+//
+//	server, err := fusemount.Mount("/mnt/workspace", client, bridgefuse.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	server.Wait()
+//
+// fusemount is a separate module because it depends on a FUSE binding
+// (github.com/hanwen/go-fuse), which would otherwise become a dependency of
+// every SDK user. This package holds the filesystem callbacks themselves:
+// FS exposes path-based Getattr/Readdir/Open/Read/Write/Flush/Release/...
+// methods with FUSE semantics, and errors convert to errnos with Errno.
+//
+// Directory attributes are cached for Options.AttrTimeout, and Mount has the
+// kernel cache them for as long. File contents are cached by the client: set
+// Options.WholeFileReads and attach a bravozero.FileCache with
+// bravozero.WithFileCache.
+package bridgefuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/DeepCreative/bravozero-go/bravozero"
+)
+
+// Options configures an FS.
+type Options struct {
+	// Root is the VFS directory exposed as the root of the mount. Defaults to "/".
+	Root string
+	// AttrTimeout is how long file attributes are cached. Defaults to 1s.
+	AttrTimeout time.Duration
+	// ReadOnly rejects all modifications with EROFS.
+	ReadOnly bool
+	// WholeFileReads loads a file in full when it is opened for reading,
+	// instead of fetching the requested range on each Read. With a
+	// bravozero.FileCache attached to the client, files that have not
+	// changed since they were cached are then not downloaded again.
+	WholeFileReads bool
+}
+
+// Attr holds the attributes of a file or directory.
+type Attr struct {
+	Mode  fs.FileMode
+	Size  int64
+	Mtime time.Time
+}
+
+// FS implements FUSE filesystem operations on top of a BridgeClient. Paths
+// passed to its methods are relative to the mount root, slash-separated,
+// with or without a leading slash. It is safe for concurrent use.
+type FS struct {
+	client *bravozero.BridgeClient
+	opts   Options
+
+	mu         sync.Mutex
+	attrs      map[string]cachedAttr
+	handles    map[uint64]*handle
+	nextHandle uint64
+}
+
+type cachedAttr struct {
+	attr    Attr
+	expires time.Time
+}
+
+// handle is an open file. Writable handles buffer the whole file and write
+// it back on Flush. Read-only handles buffer it with WholeFileReads, or on
+// encrypted paths, which cannot be read by range.
+type handle struct {
+	path     string
+	writable bool
+	buffered bool
+	data     []byte
+	dirty    bool
+	// gen counts modifications, so Flush can tell whether the buffer changed
+	// while it was being uploaded.
+	gen uint64
+}
+
+// New returns an FS serving client's VFS.
+func New(client *bravozero.BridgeClient, opts Options) *FS {
+	if opts.Root == "" {
+		opts.Root = "/"
+	}
+	if opts.AttrTimeout <= 0 {
+		opts.AttrTimeout = time.Second
+	}
+	return &FS{
+		client:  client,
+		opts:    opts,
+		attrs:   make(map[string]cachedAttr),
+		handles: make(map[uint64]*handle),
+	}
+}
+
+func (f *FS) remote(name string) string {
+	return pathpkg.Join(f.opts.Root, pathpkg.Clean("/"+name))
+}
+
+// Getattr returns the attributes of name.
+func (f *FS) Getattr(ctx context.Context, name string) (Attr, error) {
+	p := f.remote(name)
+	if p == pathpkg.Clean(f.opts.Root) {
+		return Attr{Mode: fs.ModeDir | 0o755}, nil
+	}
+
+	f.mu.Lock()
+	cached, ok := f.attrs[p]
+	f.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.attr, nil
+	}
+
+	// Listing the parent caches the attributes of all siblings at once,
+	// which suits the stat-every-entry pattern of ls and friends.
+	if _, err := f.readdir(ctx, pathpkg.Dir(p)); err != nil {
+		return Attr{}, err
+	}
+
+	f.mu.Lock()
+	cached, ok = f.attrs[p]
+	f.mu.Unlock()
+	if !ok {
+		return Attr{}, &bravozero.NotFoundError{Resource: "file", ID: p}
+	}
+	return cached.attr, nil
+}
+
+// Readdir lists the directory name.
+func (f *FS) Readdir(ctx context.Context, name string) ([]bravozero.FileInfo, error) {
+	return f.readdir(ctx, f.remote(name))
+}
+
+func (f *FS) readdir(ctx context.Context, p string) ([]bravozero.FileInfo, error) {
+	listing, err := f.client.ListFiles(ctx, p, false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	expires := time.Now().Add(f.opts.AttrTimeout)
+	f.mu.Lock()
+	for _, info := range listing.Files {
+		f.attrs[info.Path] = cachedAttr{attr: toAttr(info), expires: expires}
+	}
+	f.mu.Unlock()
+
+	return listing.Files, nil
+}
+
+func toAttr(info bravozero.FileInfo) Attr {
+	mode := fs.FileMode(0o644)
+	if info.IsDirectory {
+		mode = fs.ModeDir | 0o755
+	}
+	return Attr{Mode: mode, Size: info.Size, Mtime: info.ModifiedAt}
+}
+
+func (f *FS) invalidate(p string) {
+	f.mu.Lock()
+	delete(f.attrs, p)
+	f.mu.Unlock()
+}
+
+// Open opens name and returns a handle for Read, Write, Flush and Release.
+// flags are the os.O_* flags passed by the kernel.
+func (f *FS) Open(ctx context.Context, name string, flags int) (uint64, error) {
+	writable := flags&(os.O_WRONLY|os.O_RDWR) != 0
+	if writable && f.opts.ReadOnly {
+		return 0, syscall.EROFS
+	}
+
+	p := f.remote(name)
+	h := &handle{path: p, writable: writable, buffered: writable || f.opts.WholeFileReads || f.client.Encrypted(p)}
+	if h.buffered && (!writable || flags&os.O_TRUNC == 0) {
+		data, err := f.client.ReadFileBytes(ctx, p)
+		if err != nil {
+			return 0, err
+		}
+		h.data = data
+	}
+	if writable && flags&os.O_TRUNC != 0 {
+		h.dirty = true
+	}
+	return f.addHandle(h), nil
+}
+
+// Create creates name as an empty file and opens it for writing.
+func (f *FS) Create(ctx context.Context, name string) (uint64, error) {
+	if f.opts.ReadOnly {
+		return 0, syscall.EROFS
+	}
+	p := f.remote(name)
+	if _, err := f.client.WriteFile(ctx, p, "", false); err != nil {
+		return 0, err
+	}
+	f.invalidate(p)
+	return f.addHandle(&handle{path: p, writable: true, buffered: true}), nil
+}
+
+func (f *FS) addHandle(h *handle) uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextHandle++
+	f.handles[f.nextHandle] = h
+	return f.nextHandle
+}
+
+func (f *FS) handle(fh uint64) (*handle, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h, ok := f.handles[fh]
+	if !ok {
+		return nil, syscall.EBADF
+	}
+	return h, nil
+}
+
+// Read reads up to size bytes at off, returning no data at or past the end
+// of the file. Unbuffered read-only handles fetch just the requested range
+// from the server.
+func (f *FS) Read(ctx context.Context, fh uint64, off int64, size int) ([]byte, error) {
+	h, err := f.handle(fh)
+	if err != nil {
+		return nil, err
+	}
+	if !h.buffered {
+		return f.client.ReadFileRange(ctx, h.path, off, int64(size))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if off >= int64(len(h.data)) {
+		return nil, nil
+	}
+	end := min(off+int64(size), int64(len(h.data)))
+	return append([]byte(nil), h.data[off:end]...), nil
+}
+
+// Write writes data at off into the handle's buffer. The file is written back
+// to the VFS on Flush.
+func (f *FS) Write(ctx context.Context, fh uint64, off int64, data []byte) (int, error) {
+	h, err := f.handle(fh)
+	if err != nil {
+		return 0, err
+	}
+	if !h.writable {
+		return 0, syscall.EBADF
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if end := off + int64(len(data)); end > int64(len(h.data)) {
+		h.data = append(h.data, make([]byte, end-int64(len(h.data)))...)
+	}
+	copy(h.data[off:], data)
+	h.dirty = true
+	h.gen++
+	return len(data), nil
+}
+
+// Truncate sets the size of the file open as fh.
+func (f *FS) Truncate(ctx context.Context, fh uint64, size int64) error {
+	h, err := f.handle(fh)
+	if err != nil {
+		return err
+	}
+	if !h.writable {
+		return syscall.EBADF
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if size <= int64(len(h.data)) {
+		h.data = h.data[:size]
+	} else {
+		h.data = append(h.data, make([]byte, size-int64(len(h.data)))...)
+	}
+	h.dirty = true
+	h.gen++
+	return nil
+}
+
+// Flush writes a modified handle's buffer back to the VFS atomically. Writes
+// made while the upload is in progress keep the handle dirty, so they are
+// written by the next Flush.
+func (f *FS) Flush(ctx context.Context, fh uint64) error {
+	h, err := f.handle(fh)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	if !h.dirty {
+		f.mu.Unlock()
+		return nil
+	}
+	data := append([]byte(nil), h.data...)
+	gen := h.gen
+	f.mu.Unlock()
+
+	if _, err := f.client.WriteFileBytes(ctx, h.path, data, false, bravozero.WithAtomicWrite()); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	if h.gen == gen {
+		h.dirty = false
+	}
+	f.mu.Unlock()
+	f.invalidate(h.path)
+	return nil
+}
+
+// Release flushes and closes a handle.
+func (f *FS) Release(ctx context.Context, fh uint64) error {
+	err := f.Flush(ctx, fh)
+	f.mu.Lock()
+	delete(f.handles, fh)
+	f.mu.Unlock()
+	return err
+}
+
+// SetMtime sets the modification time of name to t.
+func (f *FS) SetMtime(ctx context.Context, name string, t time.Time) error {
+	if f.opts.ReadOnly {
+		return syscall.EROFS
+	}
+	p := f.remote(name)
+	_, err := f.client.SetMtime(ctx, p, t)
+	f.invalidate(p)
+	return err
+}
+
+// Mkdir creates the directory name.
+func (f *FS) Mkdir(ctx context.Context, name string) error {
+	if f.opts.ReadOnly {
+		return syscall.EROFS
+	}
+	p := f.remote(name)
+	_, err := f.client.Mkdir(ctx, p)
+	f.invalidate(p)
+	return err
+}
+
+// Unlink deletes the file name.
+func (f *FS) Unlink(ctx context.Context, name string) error {
+	if f.opts.ReadOnly {
+		return syscall.EROFS
+	}
+	p := f.remote(name)
+	err := f.client.DeleteFile(ctx, p)
+	f.invalidate(p)
+	return err
+}
+
+// Rmdir deletes the directory name, which must be empty.
+func (f *FS) Rmdir(ctx context.Context, name string) error {
+	if f.opts.ReadOnly {
+		return syscall.EROFS
+	}
+	p := f.remote(name)
+	entries, err := f.client.ListFiles(ctx, p, false, "")
+	if err != nil {
+		return err
+	}
+	if len(entries.Files) > 0 {
+		return syscall.ENOTEMPTY
+	}
+	err = f.client.DeleteFile(ctx, p)
+	f.invalidate(p)
+	return err
+}
+
+// Rename moves oldName to newName with a server-side copy and delete. The
+// source is only deleted once the copy has succeeded.
+func (f *FS) Rename(ctx context.Context, oldName, newName string) error {
+	if f.opts.ReadOnly {
+		return syscall.EROFS
+	}
+	src, dst := f.remote(oldName), f.remote(newName)
+	defer f.invalidate(src)
+	defer f.invalidate(dst)
+
+	results, err := f.client.BatchOps(ctx, []bravozero.FileOp{
+		{Type: bravozero.FileOpCopy, Path: src, Destination: dst},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results) != 1 {
+		return fmt.Errorf("copy of %s returned %d results", src, len(results))
+	}
+	if results[0].Error != "" {
+		return errors.New(results[0].Error)
+	}
+
+	return f.client.RemoveAll(ctx, src)
+}
+
+// Errno converts an error returned by FS to the errno a FUSE binding should
+// report to the kernel.
+func Errno(err error) syscall.Errno {
+	var errno syscall.Errno
+	var locked *bravozero.FileLockedError
+	var precondition *bravozero.PreconditionFailedError
+	var rateLimit *bravozero.RateLimitError
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &errno):
+		return errno
+	case errors.Is(err, fs.ErrNotExist):
+		return syscall.ENOENT
+	case errors.As(err, &locked), errors.As(err, &precondition), errors.As(err, &rateLimit):
+		return syscall.EAGAIN
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return syscall.EINTR
+	default:
+		return syscall.EIO
+	}
+}
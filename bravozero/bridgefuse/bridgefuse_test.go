@@ -0,0 +1,312 @@
+package bridgefuse
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	pathpkg "path"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/DeepCreative/bravozero-go/bravozero"
+)
+
+// fakeBridge is an in-memory Forge Bridge serving the endpoints FS uses.
+type fakeBridge struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	// failCopy makes every batch copy fail.
+	failCopy bool
+	// onPut is called once, while the next PUT /file is being handled and
+	// before it is applied.
+	onPut    func()
+	puts     int
+	requests []string
+}
+
+func newTestFS(t *testing.T, files map[string]string) (*FS, *fakeBridge) {
+	t.Helper()
+	fb := &fakeBridge{files: make(map[string][]byte)}
+	for p, content := range files {
+		fb.files[p] = []byte(content)
+	}
+	server := httptest.NewServer(fb)
+	t.Cleanup(server.Close)
+
+	client := bravozero.NewBridgeClient(server.URL, "key", "agent", nil, 5)
+	return New(client, Options{}), fb
+}
+
+func (fb *fakeBridge) content(p string) (string, bool) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	data, ok := fb.files[p]
+	return string(data), ok
+}
+
+func (fb *fakeBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/v1/bridge")
+	p := r.URL.Query().Get("path")
+
+	fb.mu.Lock()
+	fb.requests = append(fb.requests, route)
+	fb.mu.Unlock()
+
+	switch route {
+	case "GET /files":
+		fb.mu.Lock()
+		var files []map[string]interface{}
+		for name, data := range fb.files {
+			if pathpkg.Dir(name) == p {
+				files = append(files, map[string]interface{}{"path": name, "name": pathpkg.Base(name), "size": len(data)})
+			}
+		}
+		fb.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"path": p, "files": files, "totalCount": len(files)})
+
+	case "GET /file/bytes":
+		data, ok := fb.content(p)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		start, end := 0, len(data)-1
+		if rng := r.Header.Get("Range"); rng != "" {
+			if n, _ := fmt.Sscanf(rng, "bytes=%d-%d", &start, &end); n == 2 {
+				end = min(end, len(data)-1)
+			}
+			if start >= len(data) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write([]byte(data[start : end+1]))
+
+	case "PUT /file":
+		var body struct {
+			Path            string `json:"path"`
+			Content         string `json:"content"`
+			ContentEncoding string `json:"contentEncoding"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		data := []byte(body.Content)
+		if body.ContentEncoding == "base64" {
+			data, _ = base64.StdEncoding.DecodeString(body.Content)
+		}
+		fb.mu.Lock()
+		onPut := fb.onPut
+		fb.onPut = nil
+		fb.mu.Unlock()
+		if onPut != nil {
+			onPut()
+		}
+
+		fb.mu.Lock()
+		fb.puts++
+		fb.files[body.Path] = data
+		fb.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"path": body.Path, "size": len(data)})
+
+	case "POST /batch":
+		var body struct {
+			Ops []bravozero.FileOp `json:"ops"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		var results []map[string]interface{}
+		fb.mu.Lock()
+		for _, op := range body.Ops {
+			data, ok := fb.files[op.Path]
+			switch {
+			case op.Type == bravozero.FileOpDelete:
+				delete(fb.files, op.Path)
+				results = append(results, map[string]interface{}{})
+			case op.Type != bravozero.FileOpCopy:
+				results = append(results, map[string]interface{}{"error": "unsupported"})
+			case fb.failCopy || !ok:
+				results = append(results, map[string]interface{}{"error": "copy failed"})
+			default:
+				fb.files[op.Destination] = data
+				results = append(results, map[string]interface{}{"file": map[string]interface{}{"path": op.Destination}})
+			}
+		}
+		fb.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+
+	case "DELETE /file":
+		fb.mu.Lock()
+		for name := range fb.files {
+			if name == p || strings.HasPrefix(name, p+"/") {
+				delete(fb.files, name)
+			}
+		}
+		fb.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "unexpected request "+route, http.StatusNotImplemented)
+	}
+}
+
+func TestOpenWriteFlush(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags int
+		off   int64
+		data  string
+		want  string
+	}{
+		{"overwrite in place", os.O_RDWR, 0, "J", "Jello world"},
+		{"extend past end", os.O_WRONLY, 11, "!", "hello world!"},
+		{"truncate on open", os.O_WRONLY | os.O_TRUNC, 0, "hi", "hi"},
+		{"write with a gap", os.O_WRONLY | os.O_TRUNC, 2, "x", "\x00\x00x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f, fb := newTestFS(t, map[string]string{"/a.txt": "hello world"})
+
+			fh, err := f.Open(ctx, "a.txt", tt.flags)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if _, err := f.Write(ctx, fh, tt.off, []byte(tt.data)); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if err := f.Release(ctx, fh); err != nil {
+				t.Fatalf("Release: %v", err)
+			}
+
+			if got, _ := fb.content("/a.txt"); got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlushBinary(t *testing.T) {
+	ctx := context.Background()
+	f, fb := newTestFS(t, nil)
+	data := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00, 0xfe}
+
+	fh, err := f.Create(ctx, "image.png")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	f.Write(ctx, fh, 0, data)
+	if err := f.Flush(ctx, fh); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if got, _ := fb.content("/image.png"); !bytes.Equal([]byte(got), data) {
+		t.Errorf("content = %x, want %x", got, data)
+	}
+}
+
+func TestFlushKeepsWritesDuringUpload(t *testing.T) {
+	ctx := context.Background()
+	f, fb := newTestFS(t, map[string]string{"/a.txt": ""})
+
+	fh, err := f.Open(ctx, "a.txt", os.O_WRONLY)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	f.Write(ctx, fh, 0, []byte("first"))
+
+	// Write again while the first Flush is uploading.
+	fb.mu.Lock()
+	fb.onPut = func() {
+		f.Write(ctx, fh, 5, []byte(" second"))
+	}
+	fb.mu.Unlock()
+	if err := f.Flush(ctx, fh); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got, _ := fb.content("/a.txt"); got != "first" {
+		t.Fatalf("content after first Flush = %q, want %q", got, "first")
+	}
+
+	if err := f.Flush(ctx, fh); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got, _ := fb.content("/a.txt"); got != "first second" {
+		t.Errorf("content after second Flush = %q, want %q", got, "first second")
+	}
+
+	if err := f.Flush(ctx, fh); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	if fb.puts != 2 {
+		t.Errorf("uploads = %d, want 2; a clean handle must not be uploaded", fb.puts)
+	}
+}
+
+func TestReadOnlyHandle(t *testing.T) {
+	ctx := context.Background()
+	f, _ := newTestFS(t, map[string]string{"/a.txt": "hello"})
+
+	fh, err := f.Open(ctx, "a.txt", os.O_RDONLY)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if got, err := f.Read(ctx, fh, 1, 3); err != nil || string(got) != "ell" {
+		t.Errorf("Read(1, 3) = %q, %v, want %q", got, err, "ell")
+	}
+	if got, err := f.Read(ctx, fh, 5, 10); err != nil || len(got) != 0 {
+		t.Errorf("Read at EOF = %q, %v, want no data", got, err)
+	}
+	if _, err := f.Write(ctx, fh, 0, []byte("x")); Errno(err) != syscall.EBADF {
+		t.Errorf("Write on read-only handle = %v, want EBADF", err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	ctx := context.Background()
+	f, fb := newTestFS(t, map[string]string{"/a.txt": "hello"})
+
+	if err := f.Rename(ctx, "a.txt", "b.txt"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if _, ok := fb.content("/a.txt"); ok {
+		t.Error("source still exists after Rename")
+	}
+	if got, _ := fb.content("/b.txt"); got != "hello" {
+		t.Errorf("destination content = %q, want %q", got, "hello")
+	}
+	if _, err := f.Getattr(ctx, "a.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Getattr of source = %v, want not found", err)
+	}
+}
+
+func TestRenameKeepsSourceWhenCopyFails(t *testing.T) {
+	ctx := context.Background()
+	f, fb := newTestFS(t, map[string]string{"/a.txt": "hello"})
+	fb.mu.Lock()
+	fb.failCopy = true
+	fb.mu.Unlock()
+
+	if err := f.Rename(ctx, "a.txt", "b.txt"); err == nil {
+		t.Fatal("Rename succeeded although the copy failed")
+	}
+	if got, _ := fb.content("/a.txt"); got != "hello" {
+		t.Errorf("source content = %q, want it untouched", got)
+	}
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	for _, req := range fb.requests {
+		if strings.HasPrefix(req, "DELETE ") {
+			t.Errorf("source was deleted after a failed copy: %s", req)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package fusemount mounts the Forge Bridge VFS as a local filesystem using
+// go-fuse. It wires a bridgefuse.FS into go-fuse's path-based API; see
+// package bridgefuse for the filesystem semantics.
+//
+// It is a separate module so that only programs that mount the VFS depend
+// on go-fuse.
+package fusemount
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fuse"
+	"github.com/hanwen/go-fuse/v2/fuse/nodefs"
+	"github.com/hanwen/go-fuse/v2/fuse/pathfs"
+
+	"github.com/DeepCreative/bravozero-go/bravozero"
+	"github.com/DeepCreative/bravozero-go/bravozero/bridgefuse"
+)
+
+// Mount mounts client's VFS read-write at dir, or read-only with
+// opts.ReadOnly, and serves it in the background. The kernel caches
+// attributes and directory entries for opts.AttrTimeout. Call Unmount on the
+// returned server to unmount, and Wait to block until it is unmounted.
+func Mount(dir string, client *bravozero.BridgeClient, opts bridgefuse.Options) (*fuse.Server, error) {
+	fsys := bridgefuse.New(client, opts)
+
+	timeout := opts.AttrTimeout
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+
+	nfs := pathfs.NewPathNodeFs(&pathFS{FileSystem: pathfs.NewDefaultFileSystem(), fs: fsys}, nil)
+	server, _, err := nodefs.MountRoot(dir, nfs.Root(), &nodefs.Options{
+		AttrTimeout:  timeout,
+		EntryTimeout: timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go server.Serve()
+	if err := server.WaitMount(); err != nil {
+		server.Unmount()
+		return nil, err
+	}
+	return server, nil
+}
+
+// pathFS adapts bridgefuse.FS to pathfs.FileSystem. Operations the VFS has
+// no equivalent for fall through to the embedded default, which reports
+// ENOSYS.
+type pathFS struct {
+	pathfs.FileSystem
+	fs *bridgefuse.FS
+}
+
+func status(err error) fuse.Status {
+	return fuse.Status(bridgefuse.Errno(err))
+}
+
+func (p *pathFS) String() string {
+	return "bravozero-bridge"
+}
+
+func (p *pathFS) GetAttr(name string, _ *fuse.Context) (*fuse.Attr, fuse.Status) {
+	attr, err := p.fs.Getattr(context.Background(), name)
+	if err != nil {
+		return nil, status(err)
+	}
+	return toFuseAttr(attr), fuse.OK
+}
+
+func toFuseAttr(attr bridgefuse.Attr) *fuse.Attr {
+	out := &fuse.Attr{
+		Mode: uint32(attr.Mode.Perm()),
+		Size: uint64(attr.Size),
+	}
+	if attr.Mode.IsDir() {
+		out.Mode |= fuse.S_IFDIR
+	} else {
+		out.Mode |= fuse.S_IFREG
+	}
+	out.SetTimes(nil, &attr.Mtime, &attr.Mtime)
+	return out
+}
+
+func (p *pathFS) OpenDir(name string, _ *fuse.Context) ([]fuse.DirEntry, fuse.Status) {
+	files, err := p.fs.Readdir(context.Background(), name)
+	if err != nil {
+		return nil, status(err)
+	}
+	entries := make([]fuse.DirEntry, len(files))
+	for i, info := range files {
+		mode := uint32(fuse.S_IFREG)
+		if info.IsDirectory {
+			mode = fuse.S_IFDIR
+		}
+		entries[i] = fuse.DirEntry{Name: info.Name, Mode: mode}
+	}
+	return entries, fuse.OK
+}
+
+func (p *pathFS) Open(name string, flags uint32, _ *fuse.Context) (nodefs.File, fuse.Status) {
+	fh, err := p.fs.Open(context.Background(), name, int(flags))
+	if err != nil {
+		return nil, status(err)
+	}
+	return p.file(fh), fuse.OK
+}
+
+func (p *pathFS) Create(name string, _ uint32, _ uint32, _ *fuse.Context) (nodefs.File, fuse.Status) {
+	fh, err := p.fs.Create(context.Background(), name)
+	if err != nil {
+		return nil, status(err)
+	}
+	return p.file(fh), fuse.OK
+}
+
+func (p *pathFS) Truncate(name string, size uint64, _ *fuse.Context) fuse.Status {
+	ctx := context.Background()
+	fh, err := p.fs.Open(ctx, name, os.O_RDWR)
+	if err != nil {
+		return status(err)
+	}
+	err = p.fs.Truncate(ctx, fh, int64(size))
+	if releaseErr := p.fs.Release(ctx, fh); err == nil {
+		err = releaseErr
+	}
+	return status(err)
+}
+
+func (p *pathFS) Utimens(name string, _ *time.Time, mtime *time.Time, _ *fuse.Context) fuse.Status {
+	if mtime == nil {
+		return fuse.OK
+	}
+	return status(p.fs.SetMtime(context.Background(), name, *mtime))
+}
+
+func (p *pathFS) Mkdir(name string, _ uint32, _ *fuse.Context) fuse.Status {
+	return status(p.fs.Mkdir(context.Background(), name))
+}
+
+func (p *pathFS) Unlink(name string, _ *fuse.Context) fuse.Status {
+	return status(p.fs.Unlink(context.Background(), name))
+}
+
+func (p *pathFS) Rmdir(name string, _ *fuse.Context) fuse.Status {
+	return status(p.fs.Rmdir(context.Background(), name))
+}
+
+func (p *pathFS) Rename(oldName, newName string, _ *fuse.Context) fuse.Status {
+	return status(p.fs.Rename(context.Background(), oldName, newName))
+}
+
+func (p *pathFS) file(fh uint64) nodefs.File {
+	return &file{File: nodefs.NewDefaultFile(), fs: p.fs, fh: fh}
+}
+
+// file is an open bridgefuse handle. GetAttr falls through to the default
+// ENOSYS, so pathfs stats the file by path instead.
+type file struct {
+	nodefs.File
+	fs *bridgefuse.FS
+	fh uint64
+}
+
+func (f *file) String() string {
+	return "bravozero-bridge-file"
+}
+
+func (f *file) Read(dest []byte, off int64) (fuse.ReadResult, fuse.Status) {
+	data, err := f.fs.Read(context.Background(), f.fh, off, len(dest))
+	if err != nil {
+		return nil, status(err)
+	}
+	return fuse.ReadResultData(data), fuse.OK
+}
+
+func (f *file) Write(data []byte, off int64) (uint32, fuse.Status) {
+	n, err := f.fs.Write(context.Background(), f.fh, off, data)
+	return uint32(n), status(err)
+}
+
+func (f *file) Truncate(size uint64) fuse.Status {
+	return status(f.fs.Truncate(context.Background(), f.fh, int64(size)))
+}
+
+func (f *file) Flush() fuse.Status {
+	return status(f.fs.Flush(context.Background(), f.fh))
+}
+
+func (f *file) Fsync(int) fuse.Status {
+	return f.Flush()
+}
+
+func (f *file) Release() {
+	f.fs.Release(context.Background(), f.fh)
+}
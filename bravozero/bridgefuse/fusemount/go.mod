@@ -0,0 +1,10 @@
+module github.com/DeepCreative/bravozero-go/bravozero/bridgefuse/fusemount
+
+go 1.21
+
+require (
+	github.com/DeepCreative/bravozero-go v0.0.0
+	github.com/hanwen/go-fuse/v2 v2.5.1
+)
+
+replace github.com/DeepCreative/bravozero-go => ../../..
@@ -4,9 +4,15 @@ package bravozero
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"time"
 )
 
+// Version is the SDK version.
+const Version = "1.0.0"
+
 // Environment constants
 const (
 	EnvProduction  = "production"
@@ -28,6 +34,20 @@ type ClientConfig struct {
 	Environment string
 	// TimeoutSeconds is the request timeout
 	TimeoutSeconds int
+	// MemoryOptions configure the Memory Service client
+	MemoryOptions []MemoryOption
+	// BridgeOptions configure the Forge Bridge client
+	BridgeOptions []BridgeOption
+	// KeyExpiryThreshold is how far ahead CheckKeyExpiry warns about key expiry
+	KeyExpiryThreshold time.Duration
+	// OnKeyExpiryWarning is called when the key nears expiry
+	OnKeyExpiryWarning func(KeyExpiryWarning)
+	// ConstitutionOptions configure the Constitution Agent client
+	ConstitutionOptions []ConstitutionOption
+	// MetricsHook receives latency metrics after every API call
+	MetricsHook func(OperationMetrics)
+	// AdaptiveTimeouts derives per-call timeouts from observed latency
+	AdaptiveTimeouts *AdaptiveTimeoutConfig
 }
 
 // ClientOption is a function that configures a Client
@@ -75,10 +95,33 @@ func WithTimeout(seconds int) ClientOption {
 	}
 }
 
+// WithConstitutionOptions sets options for the Constitution Agent client
+func WithConstitutionOptions(opts ...ConstitutionOption) ClientOption {
+	return func(c *ClientConfig) {
+		c.ConstitutionOptions = append(c.ConstitutionOptions, opts...)
+	}
+}
+
+// WithMemoryOptions sets options for the Memory Service client
+func WithMemoryOptions(opts ...MemoryOption) ClientOption {
+	return func(c *ClientConfig) {
+		c.MemoryOptions = append(c.MemoryOptions, opts...)
+	}
+}
+
+// WithBridgeOptions sets options for the Forge Bridge client
+func WithBridgeOptions(opts ...BridgeOption) ClientOption {
+	return func(c *ClientConfig) {
+		c.BridgeOptions = append(c.BridgeOptions, opts...)
+	}
+}
+
 // Client is the main Bravo Zero client providing access to all services.
 type Client struct {
 	config        ClientConfig
 	authenticator *PersonaAuthenticator
+	tracker       *latencyTracker
+	keys          *keyExpiryMonitor
 	constitution  *ConstitutionClient
 	memory        *MemoryClient
 	bridge        *BridgeClient
@@ -130,10 +173,13 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		}
 	}
 
-	return &Client{
+	c := &Client{
 		config:        config,
 		authenticator: auth,
-	}, nil
+		tracker:       newLatencyTracker(config.AdaptiveTimeouts, config.MetricsHook),
+	}
+	c.keys = newKeyExpiryMonitor(c, c.tracker)
+	return c, nil
 }
 
 func getBaseURL(env string) string {
@@ -156,6 +202,7 @@ func (c *Client) Constitution() *ConstitutionClient {
 			c.config.AgentID,
 			c.authenticator,
 			c.config.TimeoutSeconds,
+			append([]ConstitutionOption{withConstitutionTransport(c.keys)}, c.config.ConstitutionOptions...)...,
 		)
 	}
 	return c.constitution
@@ -170,6 +217,7 @@ func (c *Client) Memory() *MemoryClient {
 			c.config.AgentID,
 			c.authenticator,
 			c.config.TimeoutSeconds,
+			append([]MemoryOption{withMemoryTransport(c.keys)}, c.config.MemoryOptions...)...,
 		)
 	}
 	return c.memory
@@ -184,6 +232,7 @@ func (c *Client) Bridge() *BridgeClient {
 			c.config.AgentID,
 			c.authenticator,
 			c.config.TimeoutSeconds,
+			append([]BridgeOption{withBridgeTransport(c.keys)}, c.config.BridgeOptions...)...,
 		)
 	}
 	return c.bridge
@@ -195,11 +244,57 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// doRequest performs a request against a platform-level endpoint that does
+// not belong to a single service client.
+func (c *Client) doRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.config.BaseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-API-Key", c.config.APIKey)
+	req.Header.Set("X-Agent-ID", c.config.AgentID)
+	req.Header.Set("User-Agent", "bravozero-go/"+Version)
+
+	if c.authenticator != nil {
+		attestation, err := c.authenticator.CreateAttestation("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create attestation: %w", err)
+		}
+		req.Header.Set("X-Persona-Attestation", attestation)
+	}
+
+	httpClient := &http.Client{Timeout: c.config.timeout(), Transport: c.keys}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode == 401 || resp.StatusCode == 403 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &AuthenticationError{Message: string(body)}
+	}
+
+	if resp.StatusCode == 429 {
+		resp.Body.Close()
+		return nil, &RateLimitError{RetryAfter: 60}
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return resp, nil
+}
+
 // Context helper for operations
 func (c *Client) contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, c.config.timeout())
 }
 
-func (c *ClientConfig) timeout() interface{} {
-	return c.TimeoutSeconds
+func (c *ClientConfig) timeout() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
 }
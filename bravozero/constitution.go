@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -36,6 +37,13 @@ type EvaluationResult struct {
 	AppliedRules   []AppliedRule `json:"appliedRules"`
 	Reasoning      string        `json:"reasoning"`
 	EvaluatedAt    time.Time     `json:"evaluatedAt"`
+	// ExecutionToken is set on permits when EvaluateRequest.RequestExecutionToken is true.
+	ExecutionToken *ExecutionToken `json:"executionToken,omitempty"`
+	// Reused is true if sampling skipped evaluation and this is an earlier verdict.
+	Reused bool `json:"reused,omitempty"`
+	// Provisional is true if the service was unreachable and the decision was
+	// made locally; see WithOfflineEvaluation.
+	Provisional bool `json:"provisional,omitempty"`
 }
 
 // OmegaScore represents the global alignment score.
@@ -46,23 +54,72 @@ type OmegaScore struct {
 	Timestamp  time.Time          `json:"timestamp"`
 }
 
+// ReasoningDetail controls how much explanation an evaluation returns.
+type ReasoningDetail string
+
+const (
+	// ReasoningNone returns only the decision and scores, with no reasoning
+	// text or applied rules. It is the cheapest to compute and transfer.
+	ReasoningNone ReasoningDetail = "none"
+	// ReasoningSummary returns a one-line reasoning and only the rules that
+	// matched.
+	ReasoningSummary ReasoningDetail = "summary"
+	// ReasoningFull returns the complete reasoning and every rule considered.
+	ReasoningFull ReasoningDetail = "full"
+)
+
 // EvaluateRequest represents a request to evaluate an action.
 type EvaluateRequest struct {
 	Action   string                 `json:"action"`
 	Context  map[string]interface{} `json:"context"`
 	Priority string                 `json:"priority"`
+	// RequestExecutionToken asks the service to issue an ExecutionToken on permit.
+	RequestExecutionToken bool `json:"requestExecutionToken,omitempty"`
+	// ExecutionTokenTTLSeconds bounds the token lifetime; the server default applies if zero.
+	ExecutionTokenTTLSeconds int `json:"executionTokenTtlSeconds,omitempty"`
+	// ActionClass groups repetitive actions for WithEvaluationSampling.
+	ActionClass string `json:"actionClass,omitempty"`
+	// ReasoningDetail limits the explanation returned; the server default
+	// applies if empty.
+	ReasoningDetail ReasoningDetail `json:"reasoningDetail,omitempty"`
 }
 
+// RuleScope is the level at which a rule is defined.
+type RuleScope string
+
+const (
+	RuleScopeOrg   RuleScope = "org"
+	RuleScopeTeam  RuleScope = "team"
+	RuleScopeAgent RuleScope = "agent"
+)
+
 // Rule represents a constitution rule.
 type Rule struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Category    string `json:"category"`
-	Priority    string `json:"priority"`
-	Condition   string `json:"condition"`
-	Action      string `json:"action"`
-	Active      bool   `json:"active"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	Priority    string    `json:"priority"`
+	Condition   string    `json:"condition"`
+	Action      string    `json:"action"`
+	Active      bool      `json:"active"`
+	Scope       RuleScope `json:"scope"`
+	// ScopeID identifies the org, team, or agent the rule is scoped to.
+	ScopeID string `json:"scopeId"`
+}
+
+// ListRulesOption narrows the rules returned by ListRules.
+type ListRulesOption func(url.Values)
+
+// WithRuleScope only returns rules defined at the given scope. If scopeID is
+// set, only rules for that org, team, or agent are returned.
+func WithRuleScope(scope RuleScope, scopeID string) ListRulesOption {
+	return func(v url.Values) {
+		v.Set("scope", string(scope))
+		if scopeID != "" {
+			v.Set("scopeId", scopeID)
+		}
+	}
 }
 
 // ConstitutionClient provides access to the Constitution Agent API.
@@ -72,6 +129,27 @@ type ConstitutionClient struct {
 	agentID       string
 	authenticator *PersonaAuthenticator
 	httpClient    *http.Client
+	sampler       *evaluationSampler
+	offline       *offlineEvaluator
+	denyAsResult  bool
+}
+
+// ConstitutionOption is a function that configures a ConstitutionClient
+type ConstitutionOption func(*ConstitutionClient)
+
+func withConstitutionTransport(rt http.RoundTripper) ConstitutionOption {
+	return func(c *ConstitutionClient) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithDenyAsResult makes Evaluate return a deny as an ordinary result
+// rather than with a *ConstitutionDeniedError, so callers can branch on
+// result.Decision and reserve err for failures to evaluate.
+func WithDenyAsResult() ConstitutionOption {
+	return func(c *ConstitutionClient) {
+		c.denyAsResult = true
+	}
 }
 
 // NewConstitutionClient creates a new Constitution Agent client.
@@ -79,8 +157,9 @@ func NewConstitutionClient(
 	baseURL, apiKey, agentID string,
 	auth *PersonaAuthenticator,
 	timeoutSeconds int,
+	opts ...ConstitutionOption,
 ) *ConstitutionClient {
-	return &ConstitutionClient{
+	c := &ConstitutionClient{
 		baseURL:       baseURL + "/v1/constitution",
 		apiKey:        apiKey,
 		agentID:       agentID,
@@ -89,6 +168,10 @@ func NewConstitutionClient(
 			Timeout: time.Duration(timeoutSeconds) * time.Second,
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *ConstitutionClient) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
@@ -109,7 +192,7 @@ func (c *ConstitutionClient) doRequest(ctx context.Context, method, path string,
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-API-Key", c.apiKey)
 	req.Header.Set("X-Agent-ID", c.agentID)
-	req.Header.Set("User-Agent", "bravozero-go/1.0.0")
+	req.Header.Set("User-Agent", "bravozero-go/"+Version)
 
 	if c.authenticator != nil {
 		attestation, err := c.authenticator.CreateAttestation("")
@@ -119,6 +202,10 @@ func (c *ConstitutionClient) doRequest(ctx context.Context, method, path string,
 		req.Header.Set("X-Persona-Attestation", attestation)
 	}
 
+	if token := executionTokenFromContext(ctx); token != "" {
+		req.Header.Set("X-Execution-Token", token)
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
@@ -132,13 +219,15 @@ func (c *ConstitutionClient) doRequest(ctx context.Context, method, path string,
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return resp, nil
 }
 
-// Evaluate evaluates an action against the constitution.
+// Evaluate evaluates an action against the constitution. A deny is returned
+// together with a *ConstitutionDeniedError unless the client was created
+// with WithDenyAsResult.
 func (c *ConstitutionClient) Evaluate(ctx context.Context, req EvaluateRequest) (*EvaluationResult, error) {
 	if req.Priority == "" {
 		req.Priority = "normal"
@@ -147,12 +236,51 @@ func (c *ConstitutionClient) Evaluate(ctx context.Context, req EvaluateRequest)
 		req.Context = make(map[string]interface{})
 	}
 
+	if result := c.sampler.reuse(req); result != nil {
+		return result, nil
+	}
+
+	result, err := c.evaluateRemote(ctx, req)
+	if err != nil {
+		result = c.offline.evaluate(ctx, err, req, c.agentID)
+		if result == nil {
+			return nil, err
+		}
+	} else {
+		c.sampler.observe(req, result)
+		c.refreshIfStale()
+	}
+
+	if result.Decision == DecisionDeny && !c.denyAsResult {
+		return result, &ConstitutionDeniedError{
+			Reasoning: result.Reasoning,
+			Result:    result,
+		}
+	}
+
+	return result, nil
+}
+
+// evaluateRemote sends req to the service for evaluation.
+func (c *ConstitutionClient) evaluateRemote(ctx context.Context, req EvaluateRequest) (*EvaluationResult, error) {
 	body := map[string]interface{}{
 		"agentId":  c.agentID,
 		"action":   req.Action,
 		"context":  req.Context,
 		"priority": req.Priority,
 	}
+	if req.RequestExecutionToken {
+		body["requestExecutionToken"] = true
+		if req.ExecutionTokenTTLSeconds > 0 {
+			body["executionTokenTtlSeconds"] = req.ExecutionTokenTTLSeconds
+		}
+	}
+	if req.ActionClass != "" {
+		body["actionClass"] = req.ActionClass
+	}
+	if req.ReasoningDetail != "" {
+		body["reasoningDetail"] = req.ReasoningDetail
+	}
 
 	resp, err := c.doRequest(ctx, "POST", "/evaluate", body)
 	if err != nil {
@@ -160,40 +288,53 @@ func (c *ConstitutionClient) Evaluate(ctx context.Context, req EvaluateRequest)
 	}
 	defer resp.Body.Close()
 
-	var data struct {
-		RequestID      string        `json:"requestId"`
-		Decision       string        `json:"decision"`
-		Confidence     float64       `json:"confidence"`
-		AlignmentScore float64       `json:"alignmentScore"`
-		AppliedRules   []AppliedRule `json:"appliedRules"`
-		Reasoning      string        `json:"reasoning"`
-		EvaluatedAt    string        `json:"evaluatedAt"`
-	}
-
+	var data rawEvaluationResult
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	evaluatedAt, _ := time.Parse(time.RFC3339, data.EvaluatedAt)
+	return data.toEvaluationResult(), nil
+}
+
+// rawEvaluationResult is the wire representation of EvaluationResult.
+type rawEvaluationResult struct {
+	RequestID      string        `json:"requestId"`
+	Decision       string        `json:"decision"`
+	Confidence     float64       `json:"confidence"`
+	AlignmentScore float64       `json:"alignmentScore"`
+	AppliedRules   []AppliedRule `json:"appliedRules"`
+	Reasoning      string        `json:"reasoning"`
+	EvaluatedAt    string        `json:"evaluatedAt"`
+	ExecutionToken *struct {
+		Token     string `json:"token"`
+		Action    string `json:"action"`
+		ExpiresAt string `json:"expiresAt"`
+	} `json:"executionToken"`
+}
+
+func (r rawEvaluationResult) toEvaluationResult() *EvaluationResult {
+	evaluatedAt, _ := time.Parse(time.RFC3339, r.EvaluatedAt)
 
 	result := &EvaluationResult{
-		RequestID:      data.RequestID,
-		Decision:       Decision(data.Decision),
-		Confidence:     data.Confidence,
-		AlignmentScore: data.AlignmentScore,
-		AppliedRules:   data.AppliedRules,
-		Reasoning:      data.Reasoning,
+		RequestID:      r.RequestID,
+		Decision:       Decision(r.Decision),
+		Confidence:     r.Confidence,
+		AlignmentScore: r.AlignmentScore,
+		AppliedRules:   r.AppliedRules,
+		Reasoning:      r.Reasoning,
 		EvaluatedAt:    evaluatedAt,
 	}
 
-	if result.Decision == DecisionDeny {
-		return result, &ConstitutionDeniedError{
-			Reasoning: result.Reasoning,
-			Result:    result,
+	if r.ExecutionToken != nil {
+		expiresAt, _ := time.Parse(time.RFC3339, r.ExecutionToken.ExpiresAt)
+		result.ExecutionToken = &ExecutionToken{
+			Token:     r.ExecutionToken.Token,
+			Action:    r.ExecutionToken.Action,
+			ExpiresAt: expiresAt,
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // GetOmega retrieves the current global Omega alignment score.
@@ -204,41 +345,49 @@ func (c *ConstitutionClient) GetOmega(ctx context.Context) (*OmegaScore, error)
 	}
 	defer resp.Body.Close()
 
-	var data struct {
-		Omega      float64            `json:"omega"`
-		Components map[string]float64 `json:"components"`
-		Trend      string             `json:"trend"`
-		Timestamp  string             `json:"timestamp"`
-	}
-
+	var data rawOmegaScore
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	timestamp, _ := time.Parse(time.RFC3339, data.Timestamp)
+	score := data.toOmegaScore()
+	return &score, nil
+}
+
+// rawOmegaScore is the wire representation of OmegaScore.
+type rawOmegaScore struct {
+	Omega      float64            `json:"omega"`
+	Components map[string]float64 `json:"components"`
+	Trend      string             `json:"trend"`
+	Timestamp  string             `json:"timestamp"`
+}
 
-	return &OmegaScore{
-		Omega:      data.Omega,
-		Components: data.Components,
-		Trend:      data.Trend,
+func (o rawOmegaScore) toOmegaScore() OmegaScore {
+	timestamp, _ := time.Parse(time.RFC3339, o.Timestamp)
+	return OmegaScore{
+		Omega:      o.Omega,
+		Components: o.Components,
+		Trend:      o.Trend,
 		Timestamp:  timestamp,
-	}, nil
+	}
 }
 
 // ListRules retrieves all constitution rules.
-func (c *ConstitutionClient) ListRules(ctx context.Context, category, priority string) ([]Rule, error) {
+func (c *ConstitutionClient) ListRules(ctx context.Context, category, priority string, opts ...ListRulesOption) ([]Rule, error) {
+	params := url.Values{}
+	if category != "" {
+		params.Set("category", category)
+	}
+	if priority != "" {
+		params.Set("priority", priority)
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+
 	path := "/rules"
-	if category != "" || priority != "" {
-		path += "?"
-		if category != "" {
-			path += "category=" + category
-		}
-		if priority != "" {
-			if category != "" {
-				path += "&"
-			}
-			path += "priority=" + priority
-		}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
 	}
 
 	resp, err := c.doRequest(ctx, "GET", path, nil)
@@ -255,6 +404,27 @@ func (c *ConstitutionClient) ListRules(ctx context.Context, category, priority s
 	return rules, nil
 }
 
+// ListEffectiveRules retrieves the merged set of org, team, and agent rules
+// that bind the given agent. If agentID is empty the client's agent is used.
+func (c *ConstitutionClient) ListEffectiveRules(ctx context.Context, agentID string) ([]Rule, error) {
+	if agentID == "" {
+		agentID = c.agentID
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/agents/"+url.PathEscape(agentID)+"/effective-rules", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rules []Rule
+	if err := json.NewDecoder(resp.Body).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return rules, nil
+}
+
 // GetRule retrieves a specific rule by ID.
 func (c *ConstitutionClient) GetRule(ctx context.Context, ruleID string) (*Rule, error) {
 	resp, err := c.doRequest(ctx, "GET", "/rules/"+ruleID, nil)
@@ -270,3 +440,71 @@ func (c *ConstitutionClient) GetRule(ctx context.Context, ruleID string) (*Rule,
 
 	return &rule, nil
 }
+
+// CreateRule creates a rule. The ID is assigned by the service; the created
+// rule is returned.
+func (c *ConstitutionClient) CreateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	rule.ID = ""
+
+	resp, err := c.doRequest(ctx, "POST", "/rules", rule)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var created Rule
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// UpdateRule replaces the rule with ID rule.ID and returns the stored rule.
+func (c *ConstitutionClient) UpdateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	if rule.ID == "" {
+		return nil, fmt.Errorf("rule ID is required")
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", "/rules/"+url.PathEscape(rule.ID), rule)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var updated Rule
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// DeleteRule deletes a rule.
+func (c *ConstitutionClient) DeleteRule(ctx context.Context, ruleID string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/rules/"+url.PathEscape(ruleID), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SetRuleActive activates or deactivates a rule without otherwise changing
+// it. Inactive rules are kept but not applied during evaluation.
+func (c *ConstitutionClient) SetRuleActive(ctx context.Context, ruleID string, active bool) (*Rule, error) {
+	body := map[string]bool{"active": active}
+
+	resp, err := c.doRequest(ctx, "PATCH", "/rules/"+url.PathEscape(ruleID), body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rule Rule
+	if err := json.NewDecoder(resp.Body).Decode(&rule); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &rule, nil
+}
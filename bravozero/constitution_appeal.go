@@ -0,0 +1,170 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// AppealStatus is the state of an appeal.
+type AppealStatus string
+
+const (
+	AppealPending  AppealStatus = "pending"
+	AppealUpheld   AppealStatus = "upheld"
+	AppealRejected AppealStatus = "rejected"
+)
+
+// Appeal contests a denied or escalated evaluation. If the appeal is upheld,
+// Result holds the overriding verdict, which may carry an execution token
+// for the original action.
+type Appeal struct {
+	ID            string       `json:"id"`
+	RequestID     string       `json:"requestId"`
+	AgentID       string       `json:"agentId"`
+	Justification string       `json:"justification"`
+	Status        AppealStatus `json:"status"`
+	// ReviewedBy and ReviewNote are set once the appeal has been decided.
+	ReviewedBy string            `json:"reviewedBy,omitempty"`
+	ReviewNote string            `json:"reviewNote,omitempty"`
+	Result     *EvaluationResult `json:"result,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	DecidedAt  time.Time         `json:"decidedAt,omitempty"`
+}
+
+type rawAppeal struct {
+	ID            string               `json:"id"`
+	RequestID     string               `json:"requestId"`
+	AgentID       string               `json:"agentId"`
+	Justification string               `json:"justification"`
+	Status        string               `json:"status"`
+	ReviewedBy    string               `json:"reviewedBy"`
+	ReviewNote    string               `json:"reviewNote"`
+	Result        *rawEvaluationResult `json:"result"`
+	CreatedAt     string               `json:"createdAt"`
+	DecidedAt     string               `json:"decidedAt"`
+}
+
+func (a rawAppeal) toAppeal() *Appeal {
+	createdAt, _ := time.Parse(time.RFC3339, a.CreatedAt)
+	decidedAt, _ := time.Parse(time.RFC3339, a.DecidedAt)
+
+	appeal := &Appeal{
+		ID:            a.ID,
+		RequestID:     a.RequestID,
+		AgentID:       a.AgentID,
+		Justification: a.Justification,
+		Status:        AppealStatus(a.Status),
+		ReviewedBy:    a.ReviewedBy,
+		ReviewNote:    a.ReviewNote,
+		CreatedAt:     createdAt,
+		DecidedAt:     decidedAt,
+	}
+	if a.Result != nil {
+		appeal.Result = a.Result.toEvaluationResult()
+	}
+	return appeal
+}
+
+// Appeal contests the evaluation with the given request ID, typically taken
+// from a ConstitutionDeniedError's Result, and returns the pending appeal.
+// Poll GetAppeal or use WaitForAppeal to learn the outcome.
+func (c *ConstitutionClient) Appeal(ctx context.Context, requestID, justification string) (*Appeal, error) {
+	if requestID == "" {
+		return nil, fmt.Errorf("request ID is required")
+	}
+	if justification == "" {
+		return nil, fmt.Errorf("justification is required")
+	}
+
+	body := map[string]string{
+		"requestId":     requestID,
+		"agentId":       c.agentID,
+		"justification": justification,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/appeals", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseAppeal(resp.Body)
+}
+
+// GetAppeal retrieves the current state of an appeal.
+func (c *ConstitutionClient) GetAppeal(ctx context.Context, appealID string) (*Appeal, error) {
+	resp, err := c.doRequest(ctx, "GET", "/appeals/"+url.PathEscape(appealID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseAppeal(resp.Body)
+}
+
+// ListAppeals lists the appeals filed by the client's agent, newest first.
+// An empty status lists appeals in every state.
+func (c *ConstitutionClient) ListAppeals(ctx context.Context, status AppealStatus) ([]Appeal, error) {
+	params := url.Values{}
+	params.Set("agentId", c.agentID)
+	if status != "" {
+		params.Set("status", string(status))
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/appeals?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Appeals []rawAppeal `json:"appeals"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	appeals := make([]Appeal, len(data.Appeals))
+	for i, a := range data.Appeals {
+		appeals[i] = *a.toAppeal()
+	}
+
+	return appeals, nil
+}
+
+// WaitForAppeal polls an appeal every pollInterval until it has been decided
+// or ctx is done, returning the last state seen.
+func (c *ConstitutionClient) WaitForAppeal(ctx context.Context, appealID string, pollInterval time.Duration) (*Appeal, error) {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	for {
+		appeal, err := c.GetAppeal(ctx, appealID)
+		if err != nil {
+			return nil, err
+		}
+		if appeal.Status != AppealPending {
+			return appeal, nil
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return appeal, ctx.Err()
+		}
+	}
+}
+
+func parseAppeal(r io.Reader) (*Appeal, error) {
+	var data rawAppeal
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return data.toAppeal(), nil
+}
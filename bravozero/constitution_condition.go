@@ -0,0 +1,584 @@
+package bravozero
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule conditions are boolean expressions over the evaluated action:
+//
+//	action == "file.delete" && context.path startsWith "/prod/"
+//	priority in ["high", "critical"] or not context.reviewed
+//
+// Identifiers are action, priority, agentId and context; any other
+// identifier is looked up in the request context, so context.x and x are
+// equivalent. Dotted names descend into nested context maps, and missing
+// values are null. Operators are ==, !=, <, <=, >, >=, in, contains,
+// startsWith, endsWith and matches (a regular expression), combined with
+// &&/and, ||/or, !/not and parentheses. Literals are strings in single or
+// double quotes, numbers, true, false, null and [lists].
+
+// ConditionError is a syntax error in a rule condition.
+type ConditionError struct {
+	// Offset is the byte offset of the error in the condition.
+	Offset int
+	// Line and Column locate the error, counting from 1. Column counts
+	// characters, not bytes.
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ConditionError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ValidateCondition checks that conditionDSL is a well-formed rule
+// condition, so tooling can lint rules before calling CreateRule. A syntax
+// error is returned as a *ConditionError.
+func ValidateCondition(conditionDSL string) error {
+	_, err := parseCondition(conditionDSL)
+	return err
+}
+
+type condTokenKind int
+
+const (
+	tokEOF condTokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokPunct
+)
+
+type condToken struct {
+	kind condTokenKind
+	text string
+	pos  int
+}
+
+func lexCondition(src string) ([]condToken, error) {
+	var tokens []condToken
+	i := 0
+	for i < len(src) {
+		ch := rune(src[i])
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case isIdentStart(src[i]):
+			start := i
+			for i < len(src) && (isIdentStart(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, condToken{tokIdent, src[start:i], start})
+		case isDigit(src[i]) || (ch == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			start := i
+			i++
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			if _, err := strconv.ParseFloat(src[start:i], 64); err != nil {
+				return nil, &ConditionError{Offset: start, Msg: fmt.Sprintf("invalid number %q", src[start:i])}
+			}
+			tokens = append(tokens, condToken{tokNumber, src[start:i], start})
+		case ch == '"' || ch == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, &ConditionError{Offset: start, Msg: "unterminated string"}
+				}
+				if src[i] == byte(ch) {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				sb.WriteByte(src[i])
+				i++
+			}
+			tokens = append(tokens, condToken{tokString, sb.String(), start})
+		case strings.HasPrefix(src[i:], "==") || strings.HasPrefix(src[i:], "!=") ||
+			strings.HasPrefix(src[i:], "<=") || strings.HasPrefix(src[i:], ">=") ||
+			strings.HasPrefix(src[i:], "&&") || strings.HasPrefix(src[i:], "||"):
+			tokens = append(tokens, condToken{tokOp, src[i : i+2], i})
+			i += 2
+		case strings.ContainsRune("<>!", ch):
+			tokens = append(tokens, condToken{tokOp, string(ch), i})
+			i++
+		case strings.ContainsRune("()[],.", ch):
+			tokens = append(tokens, condToken{tokPunct, string(ch), i})
+			i++
+		default:
+			return nil, &ConditionError{Offset: i, Msg: fmt.Sprintf("unexpected character %q", ch)}
+		}
+	}
+	return append(tokens, condToken{tokEOF, "", len(src)}), nil
+}
+
+func isIdentStart(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// condExpr is a parsed condition expression.
+type condExpr interface {
+	eval(env map[string]interface{}) interface{}
+}
+
+type (
+	condLiteral struct{ value interface{} }
+	condPath    struct{ names []string }
+	condList    struct{ items []condExpr }
+	condNot     struct{ x condExpr }
+	condLogical struct {
+		op   string
+		x, y condExpr
+	}
+	condCompare struct {
+		op   string
+		x, y condExpr
+		re   *regexp.Regexp
+	}
+)
+
+// comparisonOps are the binary operators that are not symbols.
+var comparisonOps = map[string]bool{
+	"in": true, "contains": true, "startsWith": true, "endsWith": true, "matches": true,
+}
+
+type condParser struct {
+	tokens []condToken
+	pos    int
+}
+
+// parseCondition parses a rule condition.
+func parseCondition(src string) (condExpr, error) {
+	expr, err := parseConditionExpr(src)
+	if cerr, ok := err.(*ConditionError); ok {
+		before := src[:min(cerr.Offset, len(src))]
+		cerr.Line = strings.Count(before, "\n") + 1
+		cerr.Column = utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+	}
+	return expr, err
+}
+
+func parseConditionExpr(src string) (condExpr, error) {
+	tokens, err := lexCondition(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &condParser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, &ConditionError{Offset: 0, Msg: "empty condition"}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &ConditionError{Offset: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	return expr, nil
+}
+
+func (p *condParser) peek() condToken {
+	return p.tokens[p.pos]
+}
+
+func (p *condParser) next() condToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of texts.
+func (p *condParser) accept(texts ...string) (condToken, bool) {
+	tok := p.peek()
+	if tok.kind == tokString || tok.kind == tokNumber {
+		return tok, false
+	}
+	for _, t := range texts {
+		if tok.text == t {
+			return p.next(), true
+		}
+	}
+	return tok, false
+}
+
+func (p *condParser) expect(text string) error {
+	if tok, ok := p.accept(text); !ok {
+		return &ConditionError{Offset: tok.pos, Msg: fmt.Sprintf("expected %q, found %s", text, describeToken(tok))}
+	}
+	return nil
+}
+
+func describeToken(tok condToken) string {
+	if tok.kind == tokEOF {
+		return "end of condition"
+	}
+	return strconv.Quote(tok.text)
+}
+
+func (p *condParser) parseOr() (condExpr, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return x, nil
+		}
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &condLogical{op: "||", x: x, y: y}
+	}
+}
+
+func (p *condParser) parseAnd() (condExpr, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return x, nil
+		}
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &condLogical{op: "&&", x: x, y: y}
+	}
+}
+
+func (p *condParser) parseNot() (condExpr, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &condNot{x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *condParser) parseComparison() (condExpr, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if !(tok.kind == tokOp && tok.text != "!" && tok.text != "&&" && tok.text != "||") &&
+		!(tok.kind == tokIdent && comparisonOps[tok.text]) {
+		return x, nil
+	}
+	p.next()
+
+	y, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	cmp := &condCompare{op: tok.text, x: x, y: y}
+
+	if tok.text == "matches" {
+		lit, ok := y.(*condLiteral)
+		pattern, isString := lit.stringValue()
+		if !ok || !isString {
+			return nil, &ConditionError{Offset: tok.pos, Msg: "matches requires a string literal pattern"}
+		}
+		if cmp.re, err = regexp.Compile(pattern); err != nil {
+			return nil, &ConditionError{Offset: tok.pos, Msg: fmt.Sprintf("invalid pattern: %v", err)}
+		}
+	}
+	return cmp, nil
+}
+
+func (p *condParser) parsePrimary() (condExpr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return &condLiteral{tok.text}, nil
+	case tokNumber:
+		f, _ := strconv.ParseFloat(tok.text, 64)
+		return &condLiteral{f}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return &condLiteral{true}, nil
+		case "false":
+			return &condLiteral{false}, nil
+		case "null":
+			return &condLiteral{nil}, nil
+		}
+		if comparisonOps[tok.text] || tok.text == "and" || tok.text == "or" || tok.text == "not" {
+			return nil, &ConditionError{Offset: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+		}
+		names := []string{tok.text}
+		for {
+			if _, ok := p.accept("."); !ok {
+				break
+			}
+			name := p.next()
+			if name.kind != tokIdent {
+				return nil, &ConditionError{Offset: name.pos, Msg: fmt.Sprintf("expected name after \".\", found %s", describeToken(name))}
+			}
+			names = append(names, name.text)
+		}
+		return &condPath{names}, nil
+	case tokPunct:
+		switch tok.text {
+		case "(":
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "[":
+			list := &condList{}
+			if _, ok := p.accept("]"); ok {
+				return list, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if _, ok := p.accept(","); ok {
+					continue
+				}
+				if err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				return list, nil
+			}
+		}
+	}
+	return nil, &ConditionError{Offset: tok.pos, Msg: fmt.Sprintf("expected a value, found %s", describeToken(tok))}
+}
+
+func (l *condLiteral) stringValue() (string, bool) {
+	if l == nil {
+		return "", false
+	}
+	s, ok := l.value.(string)
+	return s, ok
+}
+
+func (l *condLiteral) eval(env map[string]interface{}) interface{} {
+	return l.value
+}
+
+func (p *condPath) eval(env map[string]interface{}) interface{} {
+	var v interface{} = env
+	names := p.names
+	if _, ok := env[names[0]]; !ok {
+		// Bare names refer to the request context.
+		v = env["context"]
+	}
+	for _, name := range names {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+func (l *condList) eval(env map[string]interface{}) interface{} {
+	values := make([]interface{}, len(l.items))
+	for i, item := range l.items {
+		values[i] = item.eval(env)
+	}
+	return values
+}
+
+func (n *condNot) eval(env map[string]interface{}) interface{} {
+	return !truthy(n.x.eval(env))
+}
+
+func (l *condLogical) eval(env map[string]interface{}) interface{} {
+	x := truthy(l.x.eval(env))
+	if l.op == "&&" {
+		return x && truthy(l.y.eval(env))
+	}
+	return x || truthy(l.y.eval(env))
+}
+
+func (c *condCompare) eval(env map[string]interface{}) interface{} {
+	x, y := c.x.eval(env), c.y.eval(env)
+	switch c.op {
+	case "==":
+		return condEqual(x, y)
+	case "!=":
+		return !condEqual(x, y)
+	case "<", "<=", ">", ">=":
+		return condOrder(c.op, x, y)
+	case "in":
+		return condContains(y, x)
+	case "contains":
+		return condContains(x, y)
+	case "startsWith", "endsWith":
+		xs, ok1 := x.(string)
+		ys, ok2 := y.(string)
+		if !ok1 || !ok2 {
+			return false
+		}
+		if c.op == "startsWith" {
+			return strings.HasPrefix(xs, ys)
+		}
+		return strings.HasSuffix(xs, ys)
+	case "matches":
+		xs, ok := x.(string)
+		return ok && c.re.MatchString(xs)
+	}
+	return false
+}
+
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	return true
+}
+
+// toFloat converts any Go or JSON number to float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func condEqual(x, y interface{}) bool {
+	if xf, ok := toFloat(x); ok {
+		yf, ok := toFloat(y)
+		return ok && xf == yf
+	}
+	switch x := x.(type) {
+	case nil:
+		return y == nil
+	case string:
+		ys, ok := y.(string)
+		return ok && x == ys
+	case bool:
+		yb, ok := y.(bool)
+		return ok && x == yb
+	}
+	return false
+}
+
+func condOrder(op string, x, y interface{}) bool {
+	var cmp int
+	if xf, ok := toFloat(x); ok {
+		yf, ok := toFloat(y)
+		if !ok {
+			return false
+		}
+		switch {
+		case xf < yf:
+			cmp = -1
+		case xf > yf:
+			cmp = 1
+		}
+	} else if xs, ok := x.(string); ok {
+		ys, ok := y.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(xs, ys)
+	} else {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// condContains reports whether the list or string container holds item.
+func condContains(container, item interface{}) bool {
+	switch c := container.(type) {
+	case []interface{}:
+		for _, v := range c {
+			if condEqual(v, item) {
+				return true
+			}
+		}
+	case []string:
+		for _, v := range c {
+			if condEqual(v, item) {
+				return true
+			}
+		}
+	case string:
+		s, ok := item.(string)
+		return ok && strings.Contains(c, s)
+	case map[string]interface{}:
+		s, ok := item.(string)
+		if ok {
+			_, found := c[s]
+			return found
+		}
+	}
+	return false
+}
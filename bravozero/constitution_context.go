@@ -0,0 +1,72 @@
+package bravozero
+
+// Context keys recognized by the constitution service. Rules can refer to
+// them directly in conditions, e.g. `dataClassification == "restricted"`.
+const (
+	ContextTargetResource     = "targetResource"
+	ContextDataClassification = "dataClassification"
+	ContextAffectedUsers      = "affectedUsers"
+	ContextToolName           = "toolName"
+)
+
+// DataClassification is the sensitivity of the data an action touches.
+type DataClassification string
+
+const (
+	ClassificationPublic       DataClassification = "public"
+	ClassificationInternal     DataClassification = "internal"
+	ClassificationConfidential DataClassification = "confidential"
+	ClassificationRestricted   DataClassification = "restricted"
+)
+
+// ContextBuilder builds an EvaluateRequest context with typed setters for
+// the fields the service recognizes, so teams agree on key names and types.
+//
+//	req.Context = bravozero.NewEvaluationContext().
+//		TargetResource("s3://reports/q3.csv").
+//		DataClassification(bravozero.ClassificationConfidential).
+//		ToolName("s3.put_object").
+//		Build()
+type ContextBuilder struct {
+	m map[string]interface{}
+}
+
+// NewEvaluationContext returns an empty ContextBuilder.
+func NewEvaluationContext() *ContextBuilder {
+	return &ContextBuilder{m: make(map[string]interface{})}
+}
+
+// TargetResource sets the resource the action operates on.
+func (b *ContextBuilder) TargetResource(resource string) *ContextBuilder {
+	b.m[ContextTargetResource] = resource
+	return b
+}
+
+// DataClassification sets the sensitivity of the data involved.
+func (b *ContextBuilder) DataClassification(class DataClassification) *ContextBuilder {
+	b.m[ContextDataClassification] = string(class)
+	return b
+}
+
+// AffectedUsers sets the IDs of the users the action affects.
+func (b *ContextBuilder) AffectedUsers(userIDs ...string) *ContextBuilder {
+	b.m[ContextAffectedUsers] = userIDs
+	return b
+}
+
+// ToolName sets the tool the agent is invoking.
+func (b *ContextBuilder) ToolName(name string) *ContextBuilder {
+	b.m[ContextToolName] = name
+	return b
+}
+
+// Set sets a field the service has no typed setter for.
+func (b *ContextBuilder) Set(key string, value interface{}) *ContextBuilder {
+	b.m[key] = value
+	return b
+}
+
+// Build returns the context map.
+func (b *ContextBuilder) Build() map[string]interface{} {
+	return b.m
+}
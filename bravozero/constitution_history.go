@@ -0,0 +1,123 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// EvaluationFilter narrows the evaluations returned by ListEvaluations. Zero
+// fields do not filter.
+type EvaluationFilter struct {
+	AgentID  string
+	Decision Decision
+	// RuleID only returns evaluations in which the rule matched.
+	RuleID string
+	From   time.Time
+	To     time.Time
+	// PageSize is the number of evaluations per page; the server chooses if zero.
+	PageSize int
+	// PageToken continues from a previous EvaluationPage.NextPageToken.
+	PageToken string
+}
+
+// EvaluationRecord is a past evaluation from the audit log.
+type EvaluationRecord struct {
+	EvaluationResult
+	AgentID string                 `json:"agentId"`
+	Action  string                 `json:"action"`
+	Context map[string]interface{} `json:"context"`
+}
+
+// EvaluationPage is one page of ListEvaluations results.
+type EvaluationPage struct {
+	Evaluations []EvaluationRecord
+	// NextPageToken is empty on the last page.
+	NextPageToken string
+}
+
+// ListEvaluations retrieves one page of past evaluations matching filter,
+// newest first. Use IterEvaluations to walk every page.
+func (c *ConstitutionClient) ListEvaluations(ctx context.Context, filter EvaluationFilter) (*EvaluationPage, error) {
+	params := url.Values{}
+	if filter.AgentID != "" {
+		params.Set("agentId", filter.AgentID)
+	}
+	if filter.Decision != "" {
+		params.Set("decision", string(filter.Decision))
+	}
+	if filter.RuleID != "" {
+		params.Set("ruleId", filter.RuleID)
+	}
+	if !filter.From.IsZero() {
+		params.Set("from", filter.From.UTC().Format(time.RFC3339))
+	}
+	if !filter.To.IsZero() {
+		params.Set("to", filter.To.UTC().Format(time.RFC3339))
+	}
+	if filter.PageSize > 0 {
+		params.Set("limit", strconv.Itoa(filter.PageSize))
+	}
+	if filter.PageToken != "" {
+		params.Set("pageToken", filter.PageToken)
+	}
+
+	path := "/evaluations"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Evaluations []struct {
+			rawEvaluationResult
+			AgentID string                 `json:"agentId"`
+			Action  string                 `json:"action"`
+			Context map[string]interface{} `json:"context"`
+		} `json:"evaluations"`
+		NextPageToken string `json:"nextPageToken"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	page := &EvaluationPage{
+		Evaluations:   make([]EvaluationRecord, len(data.Evaluations)),
+		NextPageToken: data.NextPageToken,
+	}
+	for i, e := range data.Evaluations {
+		page.Evaluations[i] = EvaluationRecord{
+			EvaluationResult: *e.toEvaluationResult(),
+			AgentID:          e.AgentID,
+			Action:           e.Action,
+			Context:          e.Context,
+		}
+	}
+
+	return page, nil
+}
+
+// IterEvaluations returns an iterator over all evaluations matching filter,
+// fetching pages lazily with ListEvaluations.
+func (c *ConstitutionClient) IterEvaluations(filter EvaluationFilter) *PageIterator[EvaluationRecord] {
+	return NewPageIterator(func(ctx context.Context, pageToken string) ([]EvaluationRecord, string, error) {
+		f := filter
+		if pageToken != "" {
+			f.PageToken = pageToken
+		}
+		page, err := c.ListEvaluations(ctx, f)
+		if err != nil {
+			return nil, "", err
+		}
+		return page.Evaluations, page.NextPageToken, nil
+	})
+}
@@ -0,0 +1,264 @@
+package bravozero
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxProvisionalEvaluations bounds the provisional decisions kept in memory
+// between calls to ReconcileProvisional.
+const maxProvisionalEvaluations = 10000
+
+// ProvisionalEvaluation is an action decided locally while the service was
+// unreachable.
+type ProvisionalEvaluation struct {
+	Request EvaluateRequest
+	Result  *EvaluationResult
+}
+
+// ReconciledEvaluation pairs a provisional decision with the service's
+// verdict on the same request.
+type ReconciledEvaluation struct {
+	Request     EvaluateRequest
+	Provisional *EvaluationResult
+	Final       *EvaluationResult
+}
+
+// Changed reports whether the service reached a different decision.
+func (r ReconciledEvaluation) Changed() bool {
+	return r.Provisional.Decision != r.Final.Decision
+}
+
+type offlineEvaluator struct {
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	rules       []cachedRule
+	fetchedAt   time.Time
+	refreshing  bool
+	provisional []ProvisionalEvaluation
+	dropped     int
+}
+
+type cachedRule struct {
+	rule Rule
+	// cond is nil if the condition cannot be evaluated locally.
+	cond condExpr
+}
+
+// WithOfflineEvaluation caches the agent's effective rules, refreshed every
+// refreshInterval, and evaluates them locally when the service is
+// unreachable. Local decisions are marked Provisional and recorded; call
+// ReconcileProvisional once the service is back to have them re-evaluated.
+// Rules whose conditions cannot be evaluated locally, or whose action the SDK
+// does not recognise, make a provisional permit an escalate.
+func WithOfflineEvaluation(refreshInterval time.Duration) ConstitutionOption {
+	return func(c *ConstitutionClient) {
+		if refreshInterval <= 0 {
+			refreshInterval = 5 * time.Minute
+		}
+		c.offline = &offlineEvaluator{refreshInterval: refreshInterval}
+	}
+}
+
+// RefreshRules reloads the rule cache used by WithOfflineEvaluation. It is
+// called automatically; call it directly to prime the cache at startup.
+func (c *ConstitutionClient) RefreshRules(ctx context.Context) error {
+	if c.offline == nil {
+		return fmt.Errorf("offline evaluation is not enabled")
+	}
+
+	rules, err := c.ListEffectiveRules(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	cached := make([]cachedRule, 0, len(rules))
+	for _, r := range rules {
+		if !r.Active {
+			continue
+		}
+		cond, _ := parseCondition(r.Condition)
+		cached = append(cached, cachedRule{rule: r, cond: cond})
+	}
+
+	c.offline.mu.Lock()
+	c.offline.rules = cached
+	c.offline.fetchedAt = time.Now()
+	c.offline.mu.Unlock()
+	return nil
+}
+
+// refreshIfStale reloads the rule cache in the background once it is older
+// than the refresh interval.
+func (c *ConstitutionClient) refreshIfStale() {
+	o := c.offline
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	if o.refreshing || time.Since(o.fetchedAt) < o.refreshInterval {
+		o.mu.Unlock()
+		return
+	}
+	o.refreshing = true
+	o.mu.Unlock()
+
+	go func() {
+		c.RefreshRules(context.Background())
+		o.mu.Lock()
+		o.refreshing = false
+		o.mu.Unlock()
+	}()
+}
+
+// evaluate decides req against the cached rules if err means the
+// service was unreachable. It returns nil if no provisional decision can be
+// made.
+func (o *offlineEvaluator) evaluate(ctx context.Context, err error, req EvaluateRequest, agentID string) *EvaluationResult {
+	if o == nil || !isUnreachable(ctx, err) {
+		return nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.fetchedAt.IsZero() {
+		return nil
+	}
+
+	env := map[string]interface{}{
+		"action":   req.Action,
+		"priority": req.Priority,
+		"agentId":  agentID,
+		"context":  req.Context,
+	}
+
+	decision := DecisionPermit
+	var applied []AppliedRule
+	var matched, unevaluated []string
+	for _, r := range o.rules {
+		verdict, known := ruleDecision(r.rule.Action)
+		if known && verdict == DecisionPermit {
+			continue
+		}
+		if !known || r.cond == nil {
+			unevaluated = append(unevaluated, r.rule.Name)
+			continue
+		}
+
+		hit := truthy(r.cond.eval(env))
+		applied = append(applied, AppliedRule{RuleID: r.rule.ID, Name: r.rule.Name, Matched: hit})
+		if !hit {
+			continue
+		}
+		matched = append(matched, r.rule.Name)
+		if verdict == DecisionDeny || decision == DecisionPermit {
+			decision = verdict
+		}
+	}
+	if decision == DecisionPermit && len(unevaluated) > 0 {
+		decision = DecisionEscalate
+	}
+
+	reasoning := fmt.Sprintf("provisional decision made offline against %d cached rules", len(o.rules))
+	if len(matched) > 0 {
+		reasoning += "; matched: " + strings.Join(matched, ", ")
+	}
+	if len(unevaluated) > 0 {
+		reasoning += "; could not evaluate locally: " + strings.Join(unevaluated, ", ")
+	}
+
+	switch req.ReasoningDetail {
+	case ReasoningNone:
+		reasoning, applied = "", nil
+	case ReasoningSummary:
+		var hits []AppliedRule
+		for _, a := range applied {
+			if a.Matched {
+				hits = append(hits, a)
+			}
+		}
+		applied = hits
+	}
+
+	result := &EvaluationResult{
+		Decision:     decision,
+		AppliedRules: applied,
+		Reasoning:    reasoning,
+		EvaluatedAt:  time.Now(),
+		Provisional:  true,
+	}
+
+	if len(o.provisional) < maxProvisionalEvaluations {
+		o.provisional = append(o.provisional, ProvisionalEvaluation{Request: req, Result: result})
+	} else {
+		o.dropped++
+	}
+
+	return result
+}
+
+// ruleDecision maps a rule's action to the decision it imposes when matched.
+func ruleDecision(action string) (Decision, bool) {
+	switch strings.ToLower(action) {
+	case "deny", "block", "reject":
+		return DecisionDeny, true
+	case "escalate", "review":
+		return DecisionEscalate, true
+	case "permit", "allow":
+		return DecisionPermit, true
+	}
+	return "", false
+}
+
+// ProvisionalEvaluations returns the provisional decisions made since the
+// last ReconcileProvisional, and how many were dropped because the in-memory
+// log was full.
+func (c *ConstitutionClient) ProvisionalEvaluations() ([]ProvisionalEvaluation, int) {
+	if c.offline == nil {
+		return nil, 0
+	}
+	c.offline.mu.Lock()
+	defer c.offline.mu.Unlock()
+	return append([]ProvisionalEvaluation(nil), c.offline.provisional...), c.offline.dropped
+}
+
+// ReconcileProvisional sends each provisional decision's request to the
+// service for evaluation and returns the results, so callers can undo or
+// report actions the service would not have permitted. Reconciled entries
+// are cleared; if the service is still unreachable, the remaining entries
+// are kept and the error is returned.
+func (c *ConstitutionClient) ReconcileProvisional(ctx context.Context) ([]ReconciledEvaluation, error) {
+	pending, _ := c.ProvisionalEvaluations()
+
+	var reconciled []ReconciledEvaluation
+	var err error
+	for _, p := range pending {
+		var final *EvaluationResult
+		final, err = c.evaluateRemote(ctx, p.Request)
+		if err != nil {
+			break
+		}
+		reconciled = append(reconciled, ReconciledEvaluation{
+			Request:     p.Request,
+			Provisional: p.Result,
+			Final:       final,
+		})
+	}
+
+	if c.offline != nil {
+		c.offline.mu.Lock()
+		c.offline.provisional = c.offline.provisional[len(reconciled):]
+		if err == nil {
+			c.offline.dropped = 0
+		}
+		c.offline.mu.Unlock()
+	}
+
+	return reconciled, err
+}
@@ -0,0 +1,112 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OmegaHandler is called for each Omega update. Handlers are called serially.
+type OmegaHandler func(OmegaScore)
+
+// SubscribeOmega streams Omega score updates to handler until ctx is
+// cancelled, returning ctx.Err(). If the stream drops, SubscribeOmega
+// reconnects with exponential backoff up to 30s, resuming via Last-Event-ID.
+// It only returns early on an error that retrying cannot fix, such as a
+// rejected API key.
+func (c *ConstitutionClient) SubscribeOmega(ctx context.Context, handler OmegaHandler) error {
+	var lastEventID string
+	failures := 0
+
+	for {
+		resp, retryable, err := c.openEventStream(ctx, "/omega/stream", lastEventID)
+		if err == nil {
+			failures = 0
+			err = readSSE(resp.Body, func(e sseEvent) error {
+				var data rawOmegaScore
+				if err := json.Unmarshal([]byte(e.Data), &data); err != nil {
+					retryable = false
+					return fmt.Errorf("failed to decode event: %w", err)
+				}
+				if e.ID != "" {
+					lastEventID = e.ID
+				}
+				handler(data.toOmegaScore())
+				return nil
+			})
+			resp.Body.Close()
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryable {
+			return err
+		}
+
+		failures++
+		backoff := 30 * time.Second
+		if failures < 6 {
+			backoff = time.Second << (failures - 1)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// GetOmegaHistory retrieves the Omega score and its components between from
+// and to, oldest first, with one point per resolution interval. Each point
+// averages the scores within its interval. A zero resolution lets the server
+// choose one suited to the range.
+func (c *ConstitutionClient) GetOmegaHistory(ctx context.Context, from, to time.Time, resolution time.Duration) ([]OmegaScore, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("to must be after from")
+	}
+
+	params := url.Values{}
+	params.Set("from", from.UTC().Format(time.RFC3339))
+	params.Set("to", to.UTC().Format(time.RFC3339))
+	if resolution > 0 {
+		params.Set("resolutionSeconds", strconv.Itoa(int(resolution.Round(time.Second)/time.Second)))
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/omega/history?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Points []rawOmegaScore `json:"points"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	points := make([]OmegaScore, len(data.Points))
+	for i, p := range data.Points {
+		points[i] = p.toOmegaScore()
+	}
+
+	return points, nil
+}
+
+// openEventStream opens a server-sent event stream. retryable reports whether
+// a failure, or the stream later ending, is worth reconnecting after.
+func (c *ConstitutionClient) openEventStream(ctx context.Context, path, lastEventID string) (resp *http.Response, retryable bool, err error) {
+	req, err := newSSERequest(ctx, c.baseURL+path, c.apiKey, c.agentID, c.authenticator, lastEventID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The stream is long-lived, so it must not inherit the per-request timeout.
+	return openSSE(&http.Client{Transport: c.httpClient.Transport}, req)
+}
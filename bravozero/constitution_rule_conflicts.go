@@ -0,0 +1,40 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RuleConflict is a pair of active rules whose conditions can both match the
+// same action while imposing contradictory decisions, such as one permitting
+// what the other denies.
+type RuleConflict struct {
+	RuleA Rule `json:"ruleA"`
+	RuleB Rule `json:"ruleB"`
+	// Reason describes the overlap.
+	Reason string `json:"reason"`
+	// Example is an evaluation context matched by both conditions, if the
+	// service could construct one.
+	Example map[string]interface{} `json:"example,omitempty"`
+}
+
+// DetectConflicts returns the pairs of active rules that conflict, so
+// maintainers can resolve them before agents hit them at runtime.
+func (c *ConstitutionClient) DetectConflicts(ctx context.Context) ([]RuleConflict, error) {
+	resp, err := c.doRequest(ctx, "GET", "/rules/conflicts", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Conflicts []RuleConflict `json:"conflicts"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.Conflicts, nil
+}
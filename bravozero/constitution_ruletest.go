@@ -0,0 +1,95 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RuleTestCase is the outcome of evaluating one sample action in TestRule.
+type RuleTestCase struct {
+	Request EvaluateRequest
+	// Matched reports whether the draft rule's condition matched the action.
+	Matched bool
+	// Contribution is the draft rule's contribution to the decision.
+	Contribution float64
+	// Decision is the verdict with the draft rule active.
+	Decision Decision
+	// BaselineDecision is the verdict under the current rules alone.
+	BaselineDecision Decision
+	Reasoning        string
+}
+
+// Changed reports whether activating the draft rule changes the verdict.
+func (t RuleTestCase) Changed() bool {
+	return t.Decision != t.BaselineDecision
+}
+
+// TestRule evaluates sampleActions against the current rules plus the draft
+// rule, without activating it or recording the evaluations, and reports for
+// each action whether the draft matched and how the decision would change.
+func (c *ConstitutionClient) TestRule(ctx context.Context, ruleDraft Rule, sampleActions []EvaluateRequest) ([]RuleTestCase, error) {
+	if len(sampleActions) == 0 {
+		return []RuleTestCase{}, nil
+	}
+
+	requests := make([]EvaluateRequest, len(sampleActions))
+	actions := make([]map[string]interface{}, len(sampleActions))
+	for i, req := range sampleActions {
+		if req.Priority == "" {
+			req.Priority = "normal"
+		}
+		if req.Context == nil {
+			req.Context = make(map[string]interface{})
+		}
+		requests[i] = req
+		actions[i] = map[string]interface{}{
+			"agentId":  c.agentID,
+			"action":   req.Action,
+			"context":  req.Context,
+			"priority": req.Priority,
+		}
+	}
+
+	body := map[string]interface{}{
+		"rule":    ruleDraft,
+		"actions": actions,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/rules/test", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Results []struct {
+			Matched          bool    `json:"matched"`
+			Contribution     float64 `json:"contribution"`
+			Decision         string  `json:"decision"`
+			BaselineDecision string  `json:"baselineDecision"`
+			Reasoning        string  `json:"reasoning"`
+		} `json:"results"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(data.Results) != len(sampleActions) {
+		return nil, fmt.Errorf("expected %d results, got %d", len(sampleActions), len(data.Results))
+	}
+
+	cases := make([]RuleTestCase, len(sampleActions))
+	for i, r := range data.Results {
+		cases[i] = RuleTestCase{
+			Request:          requests[i],
+			Matched:          r.Matched,
+			Contribution:     r.Contribution,
+			Decision:         Decision(r.Decision),
+			BaselineDecision: Decision(r.BaselineDecision),
+			Reasoning:        r.Reasoning,
+		}
+	}
+
+	return cases, nil
+}
@@ -0,0 +1,174 @@
+package bravozero
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// maxSkippedEvaluations bounds the audit log of skipped evaluations kept in
+// memory between calls to ReportSkippedEvaluations.
+const maxSkippedEvaluations = 10000
+
+// DefaultSamplingMaxAge is how long a permit may be reused by
+// WithEvaluationSampling when no max age is configured.
+const DefaultSamplingMaxAge = 5 * time.Minute
+
+// SkippedEvaluation records an action that reused a previous verdict instead
+// of being sent for full evaluation.
+type SkippedEvaluation struct {
+	ActionClass     string                 `json:"actionClass"`
+	Action          string                 `json:"action"`
+	Context         map[string]interface{} `json:"context"`
+	ReusedRequestID string                 `json:"reusedRequestId"`
+	SkippedAt       time.Time              `json:"skippedAt"`
+}
+
+type evaluationSampler struct {
+	mu      sync.Mutex
+	rates   map[string]float64
+	maxAge  time.Duration
+	latest  map[string]sampledPermit
+	skipped []SkippedEvaluation
+	dropped int
+}
+
+// sampledPermit is the latest permit seen for an action class.
+type sampledPermit struct {
+	result     *EvaluationResult
+	observedAt time.Time
+}
+
+func (c *ConstitutionClient) evaluationSampler() *evaluationSampler {
+	if c.sampler == nil {
+		c.sampler = &evaluationSampler{
+			rates:  make(map[string]float64),
+			maxAge: DefaultSamplingMaxAge,
+			latest: make(map[string]sampledPermit),
+		}
+	}
+	return c.sampler
+}
+
+// WithEvaluationSampling sends only the given fraction (0 to 1) of
+// evaluations for actionClass to the service. Other evaluations of that
+// class reuse the latest permit verdict and are recorded for audit; see
+// ReportSkippedEvaluations. Deny and escalate verdicts are never reused, nor
+// are permits older than the max age (DefaultSamplingMaxAge unless set with
+// WithEvaluationSamplingMaxAge). Set EvaluateRequest.ActionClass to opt an
+// action into sampling.
+func WithEvaluationSampling(actionClass string, rate float64) ConstitutionOption {
+	return func(c *ConstitutionClient) {
+		c.evaluationSampler().rates[actionClass] = rate
+	}
+}
+
+// WithEvaluationSamplingMaxAge sets how long a permit may be reused by
+// WithEvaluationSampling before the next evaluation of its class is sent to
+// the service.
+func WithEvaluationSamplingMaxAge(maxAge time.Duration) ConstitutionOption {
+	return func(c *ConstitutionClient) {
+		c.evaluationSampler().maxAge = maxAge
+	}
+}
+
+// reuse returns a copy of the latest permit for req's class if this call is
+// not sampled, recording the skip. The copy keeps the reused evaluation's
+// RequestID but never its execution token, which is bound to that action.
+func (s *evaluationSampler) reuse(req EvaluateRequest) *EvaluationResult {
+	if s == nil || req.ActionClass == "" || req.RequestExecutionToken {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rate, ok := s.rates[req.ActionClass]
+	if !ok {
+		return nil
+	}
+	permit, ok := s.latest[req.ActionClass]
+	if !ok || time.Since(permit.observedAt) > s.maxAge {
+		delete(s.latest, req.ActionClass)
+		return nil
+	}
+	if rand.Float64() < rate {
+		return nil
+	}
+	latest := permit.result
+
+	if len(s.skipped) < maxSkippedEvaluations {
+		s.skipped = append(s.skipped, SkippedEvaluation{
+			ActionClass:     req.ActionClass,
+			Action:          req.Action,
+			Context:         req.Context,
+			ReusedRequestID: latest.RequestID,
+			SkippedAt:       time.Now(),
+		})
+	} else {
+		s.dropped++
+	}
+
+	result := *latest
+	result.ExecutionToken = nil
+	result.Reused = true
+	return &result
+}
+
+func (s *evaluationSampler) observe(req EvaluateRequest, result *EvaluationResult) {
+	if s == nil || req.ActionClass == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rates[req.ActionClass]; !ok {
+		return
+	}
+	if result.Decision == DecisionPermit {
+		s.latest[req.ActionClass] = sampledPermit{result: result, observedAt: time.Now()}
+	} else {
+		delete(s.latest, req.ActionClass)
+	}
+}
+
+// SkippedEvaluations returns the skipped evaluations recorded since the last
+// successful ReportSkippedEvaluations, and how many were dropped because the
+// in-memory log was full.
+func (c *ConstitutionClient) SkippedEvaluations() ([]SkippedEvaluation, int) {
+	if c.sampler == nil {
+		return nil, 0
+	}
+	c.sampler.mu.Lock()
+	defer c.sampler.mu.Unlock()
+	return append([]SkippedEvaluation(nil), c.sampler.skipped...), c.sampler.dropped
+}
+
+// ReportSkippedEvaluations uploads the skipped evaluations to the audit log
+// so they can be reconciled, then clears them locally.
+func (c *ConstitutionClient) ReportSkippedEvaluations(ctx context.Context) error {
+	skipped, dropped := c.SkippedEvaluations()
+	if len(skipped) == 0 && dropped == 0 {
+		return nil
+	}
+
+	body := map[string]interface{}{
+		"agentId": c.agentID,
+		"skipped": skipped,
+		"dropped": dropped,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/audit/skipped", body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	c.sampler.mu.Lock()
+	c.sampler.skipped = c.sampler.skipped[len(skipped):]
+	c.sampler.dropped -= dropped
+	c.sampler.mu.Unlock()
+	return nil
+}
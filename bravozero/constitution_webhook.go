@@ -0,0 +1,273 @@
+package bravozero
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookEventType is a constitution event a webhook can subscribe to.
+type WebhookEventType string
+
+const (
+	WebhookDeny     WebhookEventType = "deny"
+	WebhookEscalate WebhookEventType = "escalate"
+	// WebhookOmegaThreshold fires when Omega crosses the threshold set with
+	// WithOmegaThreshold, in either direction.
+	WebhookOmegaThreshold WebhookEventType = "omega_threshold_crossed"
+)
+
+// WebhookSignatureHeader is the request header carrying a webhook delivery's
+// signature, in the form "t=<unix seconds>,v1=<hex HMAC-SHA256>". The HMAC is
+// computed with the webhook secret over "<t>.<body>".
+const WebhookSignatureHeader = "X-BravoZero-Signature"
+
+// ErrWebhookSignature is returned when a webhook delivery's signature is
+// missing, malformed, expired or does not match.
+var ErrWebhookSignature = errors.New("invalid webhook signature")
+
+// ErrWebhookTooLarge is returned by ParseWebhook when a delivery's body
+// exceeds MaxWebhookBodySize.
+var ErrWebhookTooLarge = errors.New("webhook body too large")
+
+// MaxWebhookBodySize is the largest webhook body ParseWebhook reads.
+const MaxWebhookBodySize = 1 << 20
+
+// Webhook is a registered webhook endpoint.
+type Webhook struct {
+	ID     string             `json:"id"`
+	URL    string             `json:"url"`
+	Events []WebhookEventType `json:"events"`
+	// OmegaThreshold is set for webhooks subscribed to WebhookOmegaThreshold.
+	OmegaThreshold *float64 `json:"omegaThreshold,omitempty"`
+	// Secret signs deliveries. It is only returned by RegisterWebhook.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type rawWebhook struct {
+	ID             string             `json:"id"`
+	URL            string             `json:"url"`
+	Events         []WebhookEventType `json:"events"`
+	OmegaThreshold *float64           `json:"omegaThreshold"`
+	Secret         string             `json:"secret"`
+	CreatedAt      string             `json:"createdAt"`
+}
+
+func (w rawWebhook) toWebhook() Webhook {
+	createdAt, _ := time.Parse(time.RFC3339, w.CreatedAt)
+	return Webhook{
+		ID:             w.ID,
+		URL:            w.URL,
+		Events:         w.Events,
+		OmegaThreshold: w.OmegaThreshold,
+		Secret:         w.Secret,
+		CreatedAt:      createdAt,
+	}
+}
+
+// WebhookOption configures RegisterWebhook.
+type WebhookOption func(map[string]interface{})
+
+// WithOmegaThreshold sets the Omega value whose crossing fires
+// WebhookOmegaThreshold events.
+func WithOmegaThreshold(threshold float64) WebhookOption {
+	return func(body map[string]interface{}) {
+		body["omegaThreshold"] = threshold
+	}
+}
+
+// RegisterWebhook registers endpointURL to receive the given constitution
+// events. The returned Webhook's Secret is needed to verify deliveries with
+// VerifyWebhookSignature and is not retrievable later.
+func (c *ConstitutionClient) RegisterWebhook(ctx context.Context, endpointURL string, events []WebhookEventType, opts ...WebhookOption) (*Webhook, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("at least one event is required")
+	}
+
+	body := map[string]interface{}{
+		"url":    endpointURL,
+		"events": events,
+	}
+	for _, opt := range opts {
+		opt(body)
+	}
+	for _, e := range events {
+		if _, ok := body["omegaThreshold"]; e == WebhookOmegaThreshold && !ok {
+			return nil, fmt.Errorf("%s requires WithOmegaThreshold", WebhookOmegaThreshold)
+		}
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/webhooks", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawWebhook
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	webhook := data.toWebhook()
+	return &webhook, nil
+}
+
+// ListWebhooks lists the registered webhooks.
+func (c *ConstitutionClient) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	resp, err := c.doRequest(ctx, "GET", "/webhooks", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Webhooks []rawWebhook `json:"webhooks"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	webhooks := make([]Webhook, len(data.Webhooks))
+	for i, w := range data.Webhooks {
+		webhooks[i] = w.toWebhook()
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook unregisters a webhook.
+func (c *ConstitutionClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/webhooks/"+url.PathEscape(webhookID), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// WebhookEvent is the payload of a webhook delivery.
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	// Evaluation is set for WebhookDeny and WebhookEscalate events.
+	Evaluation *EvaluationRecord `json:"evaluation,omitempty"`
+	// Omega is set for WebhookOmegaThreshold events.
+	Omega *OmegaScore `json:"omega,omitempty"`
+}
+
+// VerifyWebhookSignature checks that body was signed with secret, given the
+// value of the WebhookSignatureHeader header. Signatures older than
+// tolerance are rejected to prevent replays; a zero tolerance defaults to
+// five minutes. An empty secret is an error, since anyone could sign with it.
+func VerifyWebhookSignature(secret, signature string, body []byte, tolerance time.Duration) error {
+	if secret == "" {
+		return fmt.Errorf("%w: webhook secret is empty", ErrWebhookSignature)
+	}
+	if tolerance <= 0 {
+		tolerance = 5 * time.Minute
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrWebhookSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookSignature
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrWebhookSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	// Several v1 signatures are sent while a secret is being rotated.
+	for _, sig := range signatures {
+		got, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(got, expected) {
+			return nil
+		}
+	}
+	return ErrWebhookSignature
+}
+
+// ParseWebhook reads a webhook delivery from r, verifies its signature with
+// secret and decodes the event. A body larger than MaxWebhookBodySize fails
+// with ErrWebhookTooLarge.
+func ParseWebhook(r *http.Request, secret string) (*WebhookEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxWebhookBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+	if len(body) > MaxWebhookBodySize {
+		return nil, ErrWebhookTooLarge
+	}
+	if err := VerifyWebhookSignature(secret, r.Header.Get(WebhookSignatureHeader), body, 0); err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		ID         string           `json:"id"`
+		Type       WebhookEventType `json:"type"`
+		Timestamp  string           `json:"timestamp"`
+		Evaluation *struct {
+			rawEvaluationResult
+			AgentID string                 `json:"agentId"`
+			Action  string                 `json:"action"`
+			Context map[string]interface{} `json:"context"`
+		} `json:"evaluation"`
+		Omega *rawOmegaScore `json:"omega"`
+	}
+
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook: %w", err)
+	}
+
+	timestamp, _ := time.Parse(time.RFC3339, data.Timestamp)
+	event := &WebhookEvent{
+		ID:        data.ID,
+		Type:      data.Type,
+		Timestamp: timestamp,
+	}
+	if e := data.Evaluation; e != nil {
+		event.Evaluation = &EvaluationRecord{
+			EvaluationResult: *e.toEvaluationResult(),
+			AgentID:          e.AgentID,
+			Action:           e.Action,
+			Context:          e.Context,
+		}
+	}
+	if data.Omega != nil {
+		omega := data.Omega.toOmegaScore()
+		event.Omega = &omega
+	}
+
+	return event, nil
+}
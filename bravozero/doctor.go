@@ -0,0 +1,224 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxClockSkew is the skew beyond which attestation timestamps are rejected.
+const maxClockSkew = 30 * time.Second
+
+// CheckStatus is the outcome of a single Doctor check.
+type CheckStatus string
+
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+)
+
+// DoctorCheck is the result of one diagnostic check.
+type DoctorCheck struct {
+	Name    string        `json:"name"`
+	Status  CheckStatus   `json:"status"`
+	Message string        `json:"message"`
+	Latency time.Duration `json:"latency,omitempty"`
+}
+
+// DoctorReport is the structured result of Client.Doctor.
+type DoctorReport struct {
+	Checks    []DoctorCheck `json:"checks"`
+	CheckedAt time.Time     `json:"checkedAt"`
+}
+
+// OK reports whether no check failed. Warnings do not count as failures.
+func (r *DoctorReport) OK() bool {
+	for _, c := range r.Checks {
+		if c.Status == CheckFail {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *DoctorReport) add(name string, status CheckStatus, latency time.Duration, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, DoctorCheck{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+		Latency: latency,
+	})
+}
+
+// Doctor runs startup diagnostics: connectivity to each service, credential
+// validity, key registration, clock skew, quota headroom, and API version
+// compatibility. Check failures are reported in the DoctorReport rather than
+// returned as errors.
+func (c *Client) Doctor(ctx context.Context) *DoctorReport {
+	report := &DoctorReport{CheckedAt: time.Now()}
+
+	var serverDate time.Time
+	for _, service := range []string{"constitution", "memory", "bridge"} {
+		start := time.Now()
+		resp, err := c.doRequest(ctx, "GET", "/v1/"+service+"/health")
+		latency := time.Since(start)
+		if err != nil {
+			report.add("connectivity:"+service, CheckFail, latency, "%v", err)
+			continue
+		}
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			serverDate = date.Add(latency / 2)
+		}
+		resp.Body.Close()
+		report.add("connectivity:"+service, CheckPass, latency, "reachable")
+	}
+
+	c.checkClockSkew(report, serverDate)
+	c.checkIdentity(ctx, report)
+	c.checkQuota(ctx, report)
+	c.checkVersion(ctx, report)
+
+	return report
+}
+
+func (c *Client) checkClockSkew(report *DoctorReport, serverDate time.Time) {
+	if serverDate.IsZero() {
+		report.add("clock-skew", CheckWarn, 0, "server time unavailable")
+		return
+	}
+	skew := time.Since(serverDate)
+	if skew < 0 {
+		skew = -skew
+	}
+	// HTTP dates have one-second resolution.
+	switch {
+	case skew > maxClockSkew:
+		report.add("clock-skew", CheckFail, 0, "local clock differs from server by %s; attestations will be rejected", skew.Round(time.Second))
+	case skew > 5*time.Second:
+		report.add("clock-skew", CheckWarn, 0, "local clock differs from server by %s", skew.Round(time.Second))
+	default:
+		report.add("clock-skew", CheckPass, 0, "within %s", skew.Round(time.Second))
+	}
+}
+
+func (c *Client) checkIdentity(ctx context.Context, report *DoctorReport) {
+	start := time.Now()
+	identity, err := c.WhoAmI(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		report.add("auth", CheckFail, latency, "%v", err)
+		return
+	}
+	report.add("auth", CheckPass, latency, "authenticated as %s", identity.AgentID)
+
+	if c.authenticator == nil {
+		report.add("key-registration", CheckWarn, 0, "no private key configured; requests are not attested")
+		return
+	}
+	if identity.PublicKey != "" && identity.PublicKey != c.authenticator.GetPublicKey() {
+		report.add("key-registration", CheckFail, 0, "local private key does not match registered key %s", identity.KeyID)
+		return
+	}
+
+	threshold := c.config.KeyExpiryThreshold
+	if threshold <= 0 {
+		threshold = DefaultKeyExpiryThreshold
+	}
+	if warning := keyExpiryWarning(identity, time.Now(), threshold); warning != nil {
+		status := CheckWarn
+		if !warning.Deadline.After(time.Now()) {
+			status = CheckFail
+		}
+		report.add("key-registration", status, 0, "%s", warning)
+		return
+	}
+	report.add("key-registration", CheckPass, 0, "key %s registered", identity.KeyID)
+}
+
+func (c *Client) checkQuota(ctx context.Context, report *DoctorReport) {
+	start := time.Now()
+	resp, err := c.doRequest(ctx, "GET", "/v1/quota")
+	latency := time.Since(start)
+	if err != nil {
+		report.add("quota", CheckWarn, latency, "quota unavailable: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Limit    int64  `json:"limit"`
+		Used     int64  `json:"used"`
+		ResetsAt string `json:"resetsAt"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		report.add("quota", CheckWarn, latency, "failed to decode quota: %v", err)
+		return
+	}
+	if data.Limit <= 0 {
+		report.add("quota", CheckPass, latency, "unlimited")
+		return
+	}
+
+	used := float64(data.Used) / float64(data.Limit)
+	switch {
+	case used >= 1:
+		report.add("quota", CheckFail, latency, "quota exhausted (%d/%d), resets at %s", data.Used, data.Limit, data.ResetsAt)
+	case used >= 0.9:
+		report.add("quota", CheckWarn, latency, "%.0f%% of quota used (%d/%d)", used*100, data.Used, data.Limit)
+	default:
+		report.add("quota", CheckPass, latency, "%.0f%% of quota used", used*100)
+	}
+}
+
+func (c *Client) checkVersion(ctx context.Context, report *DoctorReport) {
+	start := time.Now()
+	resp, err := c.doRequest(ctx, "GET", "/v1/version")
+	latency := time.Since(start)
+	if err != nil {
+		report.add("api-version", CheckWarn, latency, "version unavailable: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		APIVersion       string `json:"apiVersion"`
+		MinClientVersion string `json:"minClientVersion"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		report.add("api-version", CheckWarn, latency, "failed to decode version: %v", err)
+		return
+	}
+
+	if data.MinClientVersion != "" && compareVersions(Version, data.MinClientVersion) < 0 {
+		report.add("api-version", CheckFail, latency, "SDK %s is older than the minimum supported %s", Version, data.MinClientVersion)
+		return
+	}
+	report.add("api-version", CheckPass, latency, "API %s, SDK %s", data.APIVersion, Version)
+}
+
+// compareVersions compares dotted numeric versions such as "1.2.3".
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
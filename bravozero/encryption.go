@@ -0,0 +1,167 @@
+package bravozero
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Keyring supplies AES-256 keys for client-side encryption. The same keyring
+// can be shared by every SDK component that encrypts data.
+type Keyring interface {
+	// CurrentKey returns the ID and key used for new encryptions.
+	CurrentKey(ctx context.Context) (keyID string, key []byte, err error)
+	// Key returns the key with the given ID, for decrypting older data.
+	Key(ctx context.Context, keyID string) ([]byte, error)
+}
+
+// StaticKeyring is a Keyring holding a fixed set of keys in memory.
+type StaticKeyring struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewStaticKeyring creates a keyring whose current key is keyID. Keys must be
+// 32 bytes long.
+func NewStaticKeyring(keyID string, key []byte) (*StaticKeyring, error) {
+	k := &StaticKeyring{keys: make(map[string][]byte)}
+	if err := k.Add(keyID, key); err != nil {
+		return nil, err
+	}
+	k.current = keyID
+	return k, nil
+}
+
+// Add registers an additional key, e.g. a retired key still needed for reads.
+func (k *StaticKeyring) Add(keyID string, key []byte) error {
+	if len(key) != 32 {
+		return fmt.Errorf("key %s must be 32 bytes, got %d", keyID, len(key))
+	}
+	if len(keyID) > 255 {
+		return fmt.Errorf("key ID too long")
+	}
+	k.keys[keyID] = append([]byte(nil), key...)
+	return nil
+}
+
+// CurrentKey implements Keyring.
+func (k *StaticKeyring) CurrentKey(ctx context.Context) (string, []byte, error) {
+	return k.current, k.keys[k.current], nil
+}
+
+// Key implements Keyring.
+func (k *StaticKeyring) Key(ctx context.Context, keyID string) ([]byte, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID: %s", keyID)
+	}
+	return key, nil
+}
+
+// encryptionMagic prefixes every encrypted blob so it can be recognized.
+const encryptionMagic = "BZE1"
+
+var errNotEncrypted = errors.New("data is not encrypted")
+
+// sealBlob encrypts plaintext with AES-GCM. The output layout is
+// magic | len(keyID) | keyID | nonce | ciphertext. If deterministic is set the
+// nonce is derived from the plaintext, so equal inputs give equal outputs.
+func sealBlob(keyID string, key, plaintext []byte, deterministic bool) ([]byte, error) {
+	if len(keyID) > 255 {
+		return nil, fmt.Errorf("key ID too long")
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if deterministic {
+		// Use a key distinct from the cipher key for nonce derivation.
+		mac := hmac.New(sha256.New, append([]byte("bravozero-nonce:"), key...))
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(encryptionMagic)+1+len(keyID)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, encryptionMagic...)
+	out = append(out, byte(len(keyID)))
+	out = append(out, keyID...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, []byte(keyID)), nil
+}
+
+// openBlob decrypts a blob produced by sealBlob, looking up its key in keyring.
+func openBlob(ctx context.Context, keyring Keyring, blob []byte) ([]byte, error) {
+	if len(blob) < len(encryptionMagic)+1 || string(blob[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, errNotEncrypted
+	}
+	blob = blob[len(encryptionMagic):]
+	idLen := int(blob[0])
+	blob = blob[1:]
+	if len(blob) < idLen {
+		return nil, fmt.Errorf("encrypted data truncated")
+	}
+	keyID := string(blob[:idLen])
+	blob = blob[idLen:]
+
+	key, err := keyring.Key(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted data truncated")
+	}
+
+	plaintext, err := gcm.Open(nil, blob[:gcm.NonceSize()], blob[gcm.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptText encrypts plaintext and returns it base64-encoded so it can be
+// stored anywhere text is expected.
+func encryptText(ctx context.Context, keyring Keyring, plaintext []byte) (string, error) {
+	keyID, key, err := keyring.CurrentKey(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	blob, err := sealBlob(keyID, key, plaintext, false)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(blob), nil
+}
+
+// decryptText reverses encryptText.
+func decryptText(ctx context.Context, keyring Keyring, text []byte) ([]byte, error) {
+	blob := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(blob, text)
+	if err != nil {
+		return nil, errNotEncrypted
+	}
+	return openBlob(ctx, keyring, blob[:n])
+}
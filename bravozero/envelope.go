@@ -0,0 +1,131 @@
+package bravozero
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// KeyWrapper encrypts and decrypts the per-object data keys used by envelope
+// encryption. Implementations typically call a KMS, so the key-encryption key
+// never leaves it; use KeyringWrapper to wrap with a locally held key.
+type KeyWrapper interface {
+	// WrapKey encrypts dataKey and returns the ID of the key-encryption key
+	// used along with the wrapped key.
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey reverses WrapKey.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// KeyringWrapper returns a KeyWrapper that wraps data keys with AES-GCM using
+// the keys in keyring.
+func KeyringWrapper(keyring Keyring) KeyWrapper {
+	return keyringWrapper{keyring}
+}
+
+type keyringWrapper struct {
+	keyring Keyring
+}
+
+func (w keyringWrapper) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	keyID, key, err := w.keyring.CurrentKey(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	wrapped, err := sealBlob(keyID, key, dataKey, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return keyID, wrapped, nil
+}
+
+func (w keyringWrapper) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	return openBlob(ctx, w.keyring, wrapped)
+}
+
+// envelopeMagic prefixes every envelope-encrypted blob.
+const envelopeMagic = "BZE2"
+
+// sealEnvelope encrypts plaintext with a fresh AES-256 data key, which is
+// wrapped with wrapper and stored alongside the ciphertext. The output layout
+// is magic | len(keyID) | keyID | len(wrapped) (2 bytes) | wrapped | nonce |
+// ciphertext, and the header up to the nonce is authenticated.
+func sealEnvelope(ctx context.Context, wrapper KeyWrapper, plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	keyID, wrapped, err := wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	if len(keyID) > 255 {
+		return nil, fmt.Errorf("key ID too long")
+	}
+	if len(wrapped) > 0xffff {
+		return nil, fmt.Errorf("wrapped data key too long")
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(envelopeMagic)+1+len(keyID)+2+len(wrapped)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, envelopeMagic...)
+	out = append(out, byte(len(keyID)))
+	out = append(out, keyID...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(wrapped)))
+	out = append(out, wrapped...)
+	header := out
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, header), nil
+}
+
+// openEnvelope decrypts a blob produced by sealEnvelope.
+func openEnvelope(ctx context.Context, wrapper KeyWrapper, blob []byte) ([]byte, error) {
+	if len(blob) < len(envelopeMagic)+1 || string(blob[:len(envelopeMagic)]) != envelopeMagic {
+		return nil, errNotEncrypted
+	}
+	rest := blob[len(envelopeMagic):]
+	idLen := int(rest[0])
+	rest = rest[1:]
+	if len(rest) < idLen+2 {
+		return nil, fmt.Errorf("encrypted data truncated")
+	}
+	keyID := string(rest[:idLen])
+	rest = rest[idLen:]
+	wrappedLen := int(binary.BigEndian.Uint16(rest))
+	rest = rest[2:]
+	if len(rest) < wrappedLen {
+		return nil, fmt.Errorf("encrypted data truncated")
+	}
+	wrapped := rest[:wrappedLen]
+	rest = rest[wrappedLen:]
+	header := blob[:len(blob)-len(rest)]
+
+	dataKey, err := wrapper.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted data truncated")
+	}
+
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
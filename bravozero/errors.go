@@ -1,6 +1,14 @@
 package bravozero
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"time"
+)
 
 // BravoZeroError is the base error type for SDK errors.
 type BravoZeroError struct {
@@ -31,6 +39,55 @@ func (e *ConstitutionDeniedError) Error() string {
 	return fmt.Sprintf("constitution denied: %s", e.Reasoning)
 }
 
+// parseConstitutionDenied parses a 403 body from a service that evaluated
+// the request against the constitution. It returns nil if body is not a deny
+// verdict.
+func parseConstitutionDenied(body []byte) *ConstitutionDeniedError {
+	var data rawEvaluationResult
+	if err := json.Unmarshal(body, &data); err != nil || Decision(data.Decision) != DecisionDeny {
+		return nil
+	}
+	result := data.toEvaluationResult()
+	return &ConstitutionDeniedError{
+		Reasoning: result.Reasoning,
+		Result:    result,
+	}
+}
+
+// EscalationRequiredError indicates a guarded operation was not performed
+// because the Constitution Agent escalated it for review.
+type EscalationRequiredError struct {
+	Action string
+	Result *EvaluationResult
+}
+
+func (e *EscalationRequiredError) Error() string {
+	return fmt.Sprintf("%s requires escalation: %s", e.Action, e.Result.Reasoning)
+}
+
+// ProvisionalDecisionError indicates a guarded operation was not performed
+// because the Constitution Agent was unreachable and the decision was made
+// offline. See WithProvisionalPermits.
+type ProvisionalDecisionError struct {
+	Action string
+	Result *EvaluationResult
+}
+
+func (e *ProvisionalDecisionError) Error() string {
+	return fmt.Sprintf("%s was only provisionally permitted: %s", e.Action, e.Result.Reasoning)
+}
+
+// HTTPError is returned for an error response the SDK has no more specific
+// error type for.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
 // AuthenticationError indicates authentication failure.
 type AuthenticationError struct {
 	Message string
@@ -40,6 +97,16 @@ func (e *AuthenticationError) Error() string {
 	return fmt.Sprintf("authentication error: %s", e.Message)
 }
 
+// KeyExpiredError indicates a request was not sent because the registered
+// signing key has expired or been revoked.
+type KeyExpiredError struct {
+	Warning KeyExpiryWarning
+}
+
+func (e *KeyExpiredError) Error() string {
+	return e.Warning.String()
+}
+
 // NotFoundError indicates resource not found.
 type NotFoundError struct {
 	Resource string
@@ -49,3 +116,146 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("%s not found: %s", e.Resource, e.ID)
 }
+
+// Is makes a NotFoundError match fs.ErrNotExist, so callers can use
+// errors.Is(err, fs.ErrNotExist) for both local and VFS files.
+func (e *NotFoundError) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+// ConflictError indicates a write was rejected because the resource changed
+// since the version supplied in IfMatch.
+type ConflictError struct {
+	ID             string
+	CurrentVersion string
+	Message        string
+}
+
+func (e *ConflictError) Error() string {
+	msg := fmt.Sprintf("conflict on %s", e.ID)
+	if e.CurrentVersion != "" {
+		msg += fmt.Sprintf(" (current version %s)", e.CurrentVersion)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func parseConflictError(body []byte) *ConflictError {
+	var data struct {
+		ID             string `json:"id"`
+		CurrentVersion string `json:"currentVersion"`
+		Message        string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return &ConflictError{Message: string(body)}
+	}
+	return &ConflictError{
+		ID:             data.ID,
+		CurrentVersion: data.CurrentVersion,
+		Message:        data.Message,
+	}
+}
+
+func withConflictID(err error, id string) error {
+	var conflict *ConflictError
+	if errors.As(err, &conflict) && conflict.ID == "" {
+		conflict.ID = id
+	}
+	return err
+}
+
+// PreconditionFailedError indicates a conditional file write was rejected
+// because the file's checksum did not satisfy IfMatch or IfNoneMatch.
+type PreconditionFailedError struct {
+	Path            string
+	CurrentChecksum string
+	Message         string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	msg := fmt.Sprintf("precondition failed on %s", e.Path)
+	if e.CurrentChecksum != "" {
+		msg += fmt.Sprintf(" (current checksum %s)", e.CurrentChecksum)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func parsePreconditionFailedError(body []byte) *PreconditionFailedError {
+	var data struct {
+		Path            string `json:"path"`
+		CurrentChecksum string `json:"currentChecksum"`
+		Message         string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return &PreconditionFailedError{Message: string(body)}
+	}
+	return &PreconditionFailedError{
+		Path:            data.Path,
+		CurrentChecksum: data.CurrentChecksum,
+		Message:         data.Message,
+	}
+}
+
+// FileLockedError indicates an operation was rejected because another agent
+// holds an advisory lock on the file.
+type FileLockedError struct {
+	Path      string
+	HeldBy    string
+	ExpiresAt time.Time
+}
+
+func (e *FileLockedError) Error() string {
+	if e.HeldBy == "" {
+		return fmt.Sprintf("%s is locked", e.Path)
+	}
+	return fmt.Sprintf("%s is locked by %s until %s", e.Path, e.HeldBy, e.ExpiresAt.Format(time.RFC3339))
+}
+
+func parseFileLockedError(body []byte) *FileLockedError {
+	var data struct {
+		Path      string `json:"path"`
+		HeldBy    string `json:"heldBy"`
+		ExpiresAt string `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return &FileLockedError{}
+	}
+	expiresAt, _ := time.Parse(time.RFC3339, data.ExpiresAt)
+	return &FileLockedError{
+		Path:      data.Path,
+		HeldBy:    data.HeldBy,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// IntegrityError indicates downloaded content did not match the checksum
+// the server sent with it.
+type IntegrityError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *IntegrityError) Error() string {
+	if e.Expected == "" {
+		return fmt.Sprintf("integrity check failed for %s: server sent no checksum", e.Path)
+	}
+	return fmt.Sprintf("integrity check failed for %s: expected sha256 %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+// isUnreachable reports whether err is a transport failure rather than an
+// error response from the service. net/http also reports a cancelled or
+// expired ctx as a transport failure; that is the caller giving up, not the
+// service being down, so it is excluded.
+func isUnreachable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
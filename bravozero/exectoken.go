@@ -0,0 +1,36 @@
+package bravozero
+
+import (
+	"context"
+	"time"
+)
+
+// ExecutionToken is a signed, time-limited token issued by Evaluate for a
+// permitted action. Presenting it when the action is performed proves the
+// action was evaluated exactly as executed.
+type ExecutionToken struct {
+	Token     string    `json:"token"`
+	Action    string    `json:"action"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired reports whether the token can no longer be used.
+func (t *ExecutionToken) Expired() bool {
+	return t == nil || !time.Now().Before(t.ExpiresAt)
+}
+
+type executionTokenKey struct{}
+
+// WithExecutionToken returns a context that attaches token to every request
+// made with it, so services can verify the action was permitted.
+func WithExecutionToken(ctx context.Context, token *ExecutionToken) context.Context {
+	if token == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, executionTokenKey{}, token.Token)
+}
+
+func executionTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(executionTokenKey{}).(string)
+	return token
+}
@@ -0,0 +1,115 @@
+package bravozero
+
+import (
+	"context"
+)
+
+// Guard evaluates mutating Memory and Bridge operations with the Constitution
+// Agent before they are performed. A denied operation fails with a
+// *ConstitutionDeniedError and an escalated one with an
+// *EscalationRequiredError, without reaching the service. Permitted
+// operations carry the issued execution token, so the service can verify
+// the evaluation.
+//
+// A provisional permit, made offline by a ConstitutionClient configured
+// WithOfflineEvaluation, fails with a *ProvisionalDecisionError unless the
+// Guard was created WithProvisionalPermits.
+//
+// Use Client.Guarded, or install a Guard on individual clients with
+// WithMemoryGuard and WithBridgeGuard.
+type Guard struct {
+	constitution     *ConstitutionClient
+	allowProvisional bool
+}
+
+// GuardOption configures a Guard.
+type GuardOption func(*Guard)
+
+// WithProvisionalPermits lets operations go ahead on a provisional permit
+// made offline while the Constitution Agent is unreachable. Such operations
+// carry no execution token.
+func WithProvisionalPermits() GuardOption {
+	return func(g *Guard) {
+		g.allowProvisional = true
+	}
+}
+
+// NewGuard returns a Guard that evaluates operations with constitution.
+func NewGuard(constitution *ConstitutionClient, opts ...GuardOption) *Guard {
+	g := &Guard{constitution: constitution}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithMemoryGuard evaluates Record, RecordBatch, Update, Delete,
+// DeleteIfMatch, Merge, ConvertType, CreateEdge, Attach, Archive, Unarchive,
+// SetRetentionPolicy, DeleteRetentionPolicy, ShareNamespace, Revoke,
+// PurgeBySubject and RestoreSnapshot with g before performing them.
+func WithMemoryGuard(g *Guard) MemoryOption {
+	return func(c *MemoryClient) {
+		c.guard = g
+	}
+}
+
+// WithBridgeGuard evaluates WriteFile, AppendFile, Create, StartUpload,
+// UploadPart, CompleteUpload, DeleteFile, RemoveAll, Restore, Mkdir,
+// MkdirAll, Touch, SetMtime, Chmod, SetAttributes, SetFileMetadata, Lock,
+// Share, Unshare, ResolveConflict, Sync, SyncPaths, BatchOps, ApplyPatch,
+// TransferTo, CopyBetweenWorkspaces and RestoreDir with g before performing
+// them. Parts written through a FileWriter are covered by the evaluation
+// made in Create.
+func WithBridgeGuard(g *Guard) BridgeOption {
+	return func(c *BridgeClient) {
+		c.guard = g
+	}
+}
+
+// check evaluates action and returns ctx carrying the execution token if it
+// is permitted. A nil Guard permits everything.
+func (g *Guard) check(ctx context.Context, action string, details map[string]interface{}) (context.Context, error) {
+	if g == nil {
+		return ctx, nil
+	}
+
+	result, err := g.constitution.Evaluate(ctx, EvaluateRequest{
+		Action:                action,
+		Context:               details,
+		RequestExecutionToken: true,
+	})
+	if err != nil {
+		return ctx, err
+	}
+	// The client may be configured WithDenyAsResult.
+	if result.Decision == DecisionDeny {
+		return ctx, &ConstitutionDeniedError{Reasoning: result.Reasoning, Result: result}
+	}
+	if result.Decision == DecisionEscalate {
+		return ctx, &EscalationRequiredError{Action: action, Result: result}
+	}
+	if result.Provisional && !g.allowProvisional {
+		return ctx, &ProvisionalDecisionError{Action: action, Result: result}
+	}
+
+	return WithExecutionToken(ctx, result.ExecutionToken), nil
+}
+
+// Guarded returns a client sharing c's configuration and Constitution client
+// whose Memory and Bridge clients evaluate every mutating operation before
+// performing it; see Guard.
+func (c *Client) Guarded(opts ...GuardOption) *Client {
+	g := NewGuard(c.Constitution(), opts...)
+
+	config := c.config
+	config.MemoryOptions = append(append([]MemoryOption(nil), c.config.MemoryOptions...), WithMemoryGuard(g))
+	config.BridgeOptions = append(append([]BridgeOption(nil), c.config.BridgeOptions...), WithBridgeGuard(g))
+
+	return &Client{
+		config:        config,
+		authenticator: c.authenticator,
+		tracker:       c.tracker,
+		keys:          c.keys,
+		constitution:  c.constitution,
+	}
+}
@@ -0,0 +1,218 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultKeyExpiryThreshold is how far ahead of expiry CheckKeyExpiry warns
+// when no threshold is configured.
+const DefaultKeyExpiryThreshold = 7 * 24 * time.Hour
+
+// Identity describes the authenticated agent and its registered signing key.
+type Identity struct {
+	AgentID      string    `json:"agentId"`
+	KeyID        string    `json:"keyId"`
+	PublicKey    string    `json:"publicKey"`
+	KeyExpiresAt time.Time `json:"keyExpiresAt,omitempty"`
+	// KeyRevokesAt is set when the key has been scheduled for revocation.
+	KeyRevokesAt time.Time `json:"keyRevokesAt,omitempty"`
+	KeyRevoked   bool      `json:"keyRevoked"`
+}
+
+// KeyExpiryWarning reports that the registered signing key will soon stop working.
+type KeyExpiryWarning struct {
+	AgentID string
+	KeyID   string
+	// Deadline is the earlier of the key's expiry and scheduled revocation.
+	Deadline time.Time
+	// Revoked is true if the deadline is a revocation rather than an expiry.
+	Revoked bool
+}
+
+func (w KeyExpiryWarning) String() string {
+	reason := "expires"
+	if w.Revoked {
+		reason = "is revoked"
+	}
+	return fmt.Sprintf("signing key %s for agent %s %s at %s", w.KeyID, w.AgentID, reason, w.Deadline.Format(time.RFC3339))
+}
+
+// WithKeyExpiryWarning registers a handler called when the registered key
+// expires or is revoked within threshold. It is called by CheckKeyExpiry, and
+// by the client before a request the first time it sees a given deadline.
+func WithKeyExpiryWarning(threshold time.Duration, handler func(KeyExpiryWarning)) ClientOption {
+	return func(c *ClientConfig) {
+		c.KeyExpiryThreshold = threshold
+		c.OnKeyExpiryWarning = handler
+	}
+}
+
+// WhoAmI returns the identity the client is authenticated as.
+func (c *Client) WhoAmI(ctx context.Context) (*Identity, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/auth/whoami")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		AgentID      string `json:"agentId"`
+		KeyID        string `json:"keyId"`
+		PublicKey    string `json:"publicKey"`
+		KeyExpiresAt string `json:"keyExpiresAt"`
+		KeyRevokesAt string `json:"keyRevokesAt"`
+		KeyRevoked   bool   `json:"keyRevoked"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	expiresAt, _ := time.Parse(time.RFC3339, data.KeyExpiresAt)
+	revokesAt, _ := time.Parse(time.RFC3339, data.KeyRevokesAt)
+
+	identity := &Identity{
+		AgentID:      data.AgentID,
+		KeyID:        data.KeyID,
+		PublicKey:    data.PublicKey,
+		KeyExpiresAt: expiresAt,
+		KeyRevokesAt: revokesAt,
+		KeyRevoked:   data.KeyRevoked,
+	}
+	c.keys.observe(identity)
+	return identity, nil
+}
+
+// CheckKeyExpiry looks up the registered key and returns a warning if it
+// expires or is revoked within the configured threshold, also passing it to
+// the handler set with WithKeyExpiryWarning. It returns nil if the key is healthy.
+//
+// The client looks up the identity before its first request and refuses
+// requests with a *KeyExpiredError once the key's deadline has passed, but it
+// only learns of a changed deadline from WhoAmI. Call CheckKeyExpiry
+// periodically, e.g. from a health check, to rotate keys before outages.
+func (c *Client) CheckKeyExpiry(ctx context.Context) (*KeyExpiryWarning, error) {
+	identity, err := c.WhoAmI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := c.config.KeyExpiryThreshold
+	if threshold <= 0 {
+		threshold = DefaultKeyExpiryThreshold
+	}
+
+	warning := keyExpiryWarning(identity, time.Now(), threshold)
+	if warning != nil && c.config.OnKeyExpiryWarning != nil {
+		c.config.OnKeyExpiryWarning(*warning)
+	}
+	return warning, nil
+}
+
+func keyExpiryWarning(identity *Identity, now time.Time, threshold time.Duration) *KeyExpiryWarning {
+	warning := &KeyExpiryWarning{AgentID: identity.AgentID, KeyID: identity.KeyID}
+
+	switch {
+	case identity.KeyRevoked:
+		warning.Revoked = true
+		warning.Deadline = now
+		if !identity.KeyRevokesAt.IsZero() {
+			warning.Deadline = identity.KeyRevokesAt
+		}
+		return warning
+	case !identity.KeyRevokesAt.IsZero() && (identity.KeyExpiresAt.IsZero() || identity.KeyRevokesAt.Before(identity.KeyExpiresAt)):
+		warning.Revoked = true
+		warning.Deadline = identity.KeyRevokesAt
+	case !identity.KeyExpiresAt.IsZero():
+		warning.Deadline = identity.KeyExpiresAt
+	default:
+		return nil
+	}
+
+	if warning.Deadline.Sub(now) > threshold {
+		return nil
+	}
+	return warning
+}
+
+// keyExpiryMonitor is the transport shared by a Client's service clients. It
+// rejects requests once the last known identity's key has expired or been
+// revoked, and reports upcoming deadlines to the configured handler.
+type keyExpiryMonitor struct {
+	base   http.RoundTripper
+	client *Client
+	lookup sync.Once
+
+	mu       sync.Mutex
+	identity *Identity
+	notified time.Time
+}
+
+func newKeyExpiryMonitor(client *Client, base http.RoundTripper) *keyExpiryMonitor {
+	return &keyExpiryMonitor{base: base, client: client}
+}
+
+// observe records the latest identity returned by WhoAmI.
+func (m *keyExpiryMonitor) observe(identity *Identity) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.identity = identity
+	m.mu.Unlock()
+}
+
+// check returns a *KeyExpiredError if the key is past its deadline. The
+// identity is looked up once; if that fails, requests proceed unchecked
+// until WhoAmI succeeds.
+func (m *keyExpiryMonitor) check(ctx context.Context) error {
+	m.lookup.Do(func() {
+		m.client.WhoAmI(ctx)
+	})
+
+	m.mu.Lock()
+	identity := m.identity
+	m.mu.Unlock()
+	if identity == nil {
+		return nil
+	}
+
+	threshold := m.client.config.KeyExpiryThreshold
+	if threshold <= 0 {
+		threshold = DefaultKeyExpiryThreshold
+	}
+	now := time.Now()
+	warning := keyExpiryWarning(identity, now, threshold)
+	if warning == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	notify := !m.notified.Equal(warning.Deadline)
+	m.notified = warning.Deadline
+	m.mu.Unlock()
+	if notify && m.client.config.OnKeyExpiryWarning != nil {
+		m.client.config.OnKeyExpiryWarning(*warning)
+	}
+
+	if !warning.Deadline.After(now) {
+		return &KeyExpiredError{Warning: *warning}
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (m *keyExpiryMonitor) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasSuffix(req.URL.Path, "/v1/auth/whoami") {
+		if err := m.check(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return m.base.RoundTrip(req)
+}
@@ -0,0 +1,124 @@
+package bravozero
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ConversationMessage is a single turn in a transcript.
+type ConversationMessage struct {
+	Role    string
+	Content string
+}
+
+// IngestOptions configures IngestConversation.
+type IngestOptions struct {
+	// ConversationID is stored in each chunk's metadata as "conversationId".
+	ConversationID string
+	// MaxChunkChars bounds the size of each chunk (default 2000). A single
+	// message longer than this becomes its own chunk.
+	MaxChunkChars int
+	// OverlapMessages repeats the last N messages of a chunk at the start of
+	// the next one to preserve context across boundaries.
+	OverlapMessages int
+	// Tags are added to every chunk in addition to "conversation".
+	Tags []string
+	// Metadata is merged into every chunk's metadata.
+	Metadata map[string]interface{}
+	// Namespace for the recorded memories; the client default applies if empty.
+	Namespace string
+	// Importance for the recorded memories; the client default applies if nil.
+	Importance *float64
+}
+
+// IngestConversation splits a transcript into chunks, records each chunk as
+// an episodic memory with consistent tags and metadata, and links
+// consecutive chunks with RelFollows edges. The recorded memories are
+// returned in transcript order.
+func (c *MemoryClient) IngestConversation(ctx context.Context, messages []ConversationMessage, opts IngestOptions) ([]Memory, error) {
+	chunks := chunkConversation(messages, opts.MaxChunkChars, opts.OverlapMessages)
+	if len(chunks) == 0 {
+		return []Memory{}, nil
+	}
+
+	tags := append([]string{"conversation"}, opts.Tags...)
+	reqs := make([]RecordRequest, len(chunks))
+	for i, chunk := range chunks {
+		metadata := make(map[string]interface{}, len(opts.Metadata)+4)
+		for k, v := range opts.Metadata {
+			metadata[k] = v
+		}
+		if opts.ConversationID != "" {
+			metadata["conversationId"] = opts.ConversationID
+		}
+		metadata["chunkIndex"] = i
+		metadata["chunkCount"] = len(chunks)
+		metadata["messageCount"] = chunk.messages
+
+		reqs[i] = RecordRequest{
+			Content:    chunk.text,
+			MemoryType: MemoryTypeEpisodic,
+			Importance: opts.Importance,
+			Namespace:  opts.Namespace,
+			Tags:       tags,
+			Metadata:   metadata,
+		}
+	}
+
+	memories, err := c.RecordBatch(ctx, reqs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record conversation chunks: %w", err)
+	}
+
+	for i := 1; i < len(memories); i++ {
+		if _, err := c.CreateEdge(ctx, memories[i].ID, memories[i-1].ID, RelFollows, 1, WithEdgeIfMatch(memories[i].Version)); err != nil {
+			return memories, fmt.Errorf("failed to link chunk %d: %w", i, err)
+		}
+	}
+
+	return memories, nil
+}
+
+type conversationChunk struct {
+	text     string
+	messages int
+}
+
+func chunkConversation(messages []ConversationMessage, maxChars, overlap int) []conversationChunk {
+	if maxChars <= 0 {
+		maxChars = 2000
+	}
+	if overlap < 0 {
+		overlap = 0
+	}
+
+	lines := make([]string, len(messages))
+	for i, m := range messages {
+		lines[i] = m.Role + ": " + m.Content
+	}
+
+	var chunks []conversationChunk
+	start := 0
+	for start < len(lines) {
+		end := start
+		size := 0
+		for end < len(lines) && (end == start || size+len(lines[end])+1 <= maxChars) {
+			size += len(lines[end]) + 1
+			end++
+		}
+		chunks = append(chunks, conversationChunk{
+			text:     strings.Join(lines[start:end], "\n"),
+			messages: end - start,
+		})
+		if end == len(lines) {
+			break
+		}
+		next := end - overlap
+		if next <= start {
+			next = start + 1
+		}
+		start = next
+	}
+	return chunks
+}
@@ -0,0 +1,108 @@
+package bravozero
+
+import "context"
+
+// DefaultCollectLimit caps CollectAll when no explicit limit is given.
+const DefaultCollectLimit = 10000
+
+// Iterator walks a sequence of results that may span several API pages.
+//
+//	for it.Next(ctx) {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] interface {
+	// Next advances to the next item, fetching a new page if needed.
+	// It returns false when the sequence is exhausted or an error occurs.
+	Next(ctx context.Context) bool
+	// Value returns the current item.
+	Value() T
+	// Err returns the first error encountered, if any.
+	Err() error
+}
+
+// PageFetcher fetches one page of results. It returns the items and the token
+// for the next page, or an empty token when there are no more pages.
+type PageFetcher[T any] func(ctx context.Context, pageToken string) ([]T, string, error)
+
+// PageIterator is an Iterator backed by a token-paginated endpoint.
+type PageIterator[T any] struct {
+	fetch     PageFetcher[T]
+	page      []T
+	index     int
+	nextToken string
+	started   bool
+	done      bool
+	current   T
+	err       error
+}
+
+// NewPageIterator returns an iterator that calls fetch lazily as pages are consumed.
+func NewPageIterator[T any](fetch PageFetcher[T]) *PageIterator[T] {
+	return &PageIterator[T]{fetch: fetch}
+}
+
+// Next implements Iterator.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.page) {
+		if it.err != nil || it.done {
+			return false
+		}
+		if it.started && it.nextToken == "" {
+			it.done = true
+			return false
+		}
+		page, next, err := it.fetch(ctx, it.nextToken)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.page = page
+		it.index = 0
+		it.nextToken = next
+		if len(page) == 0 && next == "" {
+			it.done = true
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value implements Iterator.
+func (it *PageIterator[T]) Value() T {
+	return it.current
+}
+
+// Err implements Iterator.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// CollectAll drains it into a slice, stopping after maxItems items. If maxItems
+// is zero or negative, DefaultCollectLimit is used. The returned bool reports
+// whether the sequence was truncated because the limit was reached. Detecting
+// truncation consumes one more item from it; that item is not in the result
+// but remains available from it.Value(), so iteration can be resumed without
+// skipping anything.
+func CollectAll[T any](ctx context.Context, it Iterator[T], maxItems int) ([]T, bool, error) {
+	if maxItems <= 0 {
+		maxItems = DefaultCollectLimit
+	}
+
+	// Grow incrementally rather than preallocating maxItems so a generous
+	// cap doesn't reserve memory the result set never needs.
+	var items []T
+	for it.Next(ctx) {
+		if len(items) == maxItems {
+			return items, true, nil
+		}
+		items = append(items, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return items, false, err
+	}
+	return items, false, nil
+}
@@ -0,0 +1,251 @@
+package bravozero
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultLatencyWindow is the number of recent samples kept per operation.
+const defaultLatencyWindow = 200
+
+// OperationMetrics is reported to the metrics hook after every API call.
+type OperationMetrics struct {
+	// Operation identifies the endpoint, e.g. "POST /memory/query". Path
+	// segments containing digits are replaced with ":id".
+	Operation  string
+	Latency    time.Duration
+	StatusCode int
+	Err        error
+	// P95 is the rolling 95th percentile latency for the operation.
+	P95 time.Duration
+	// Timeout is the adaptive timeout applied to the call, if any.
+	Timeout time.Duration
+}
+
+// AdaptiveTimeoutConfig bounds per-call timeouts derived from observed latency.
+type AdaptiveTimeoutConfig struct {
+	// Multiplier is applied to the rolling p95 latency (default 3).
+	Multiplier float64
+	// Min and Max clamp the derived timeout.
+	Min time.Duration
+	Max time.Duration
+	// MinSamples is the number of samples required before adapting (default 20).
+	MinSamples int
+}
+
+// WithMetricsHook registers a function called with latency metrics after
+// every API call.
+func WithMetricsHook(hook func(OperationMetrics)) ClientOption {
+	return func(c *ClientConfig) {
+		c.MetricsHook = hook
+	}
+}
+
+// WithAdaptiveTimeouts derives each call's timeout from the operation's
+// observed p95 latency, within the configured bounds. Calls whose context
+// already has an earlier deadline are unaffected.
+func WithAdaptiveTimeouts(cfg AdaptiveTimeoutConfig) ClientOption {
+	return func(c *ClientConfig) {
+		c.AdaptiveTimeouts = &cfg
+	}
+}
+
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % len(w.samples)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+func (w *latencyWindow) count() int {
+	if w.full {
+		return len(w.samples)
+	}
+	return w.next
+}
+
+func (w *latencyWindow) p95() time.Duration {
+	n := w.count()
+	if n == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, n)
+	copy(sorted, w.samples[:n])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(n*95-1)/100]
+}
+
+// latencyTracker is an http.RoundTripper that records per-operation latency
+// and optionally applies adaptive timeouts.
+type latencyTracker struct {
+	base     http.RoundTripper
+	adaptive *AdaptiveTimeoutConfig
+	hook     func(OperationMetrics)
+
+	mu      sync.Mutex
+	windows map[string]*latencyWindow
+}
+
+func newLatencyTracker(adaptive *AdaptiveTimeoutConfig, hook func(OperationMetrics)) *latencyTracker {
+	if adaptive != nil {
+		if adaptive.Multiplier <= 0 {
+			adaptive.Multiplier = 3
+		}
+		if adaptive.MinSamples <= 0 {
+			adaptive.MinSamples = 20
+		}
+	}
+	return &latencyTracker{
+		base:     http.DefaultTransport,
+		adaptive: adaptive,
+		hook:     hook,
+		windows:  make(map[string]*latencyWindow),
+	}
+}
+
+func operationName(req *http.Request) string {
+	segments := strings.Split(strings.TrimPrefix(req.URL.Path, "/v1"), "/")
+	for i, seg := range segments {
+		if strings.ContainsAny(seg, "0123456789") {
+			segments[i] = ":id"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// P95 returns the rolling p95 latency for op and the number of samples.
+func (t *latencyTracker) P95(op string) (time.Duration, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.windows[op]
+	if !ok {
+		return 0, 0
+	}
+	return w.p95(), w.count()
+}
+
+func (t *latencyTracker) snapshot() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make(map[string]time.Duration, len(t.windows))
+	for op, w := range t.windows {
+		out[op] = w.p95()
+	}
+	return out
+}
+
+func (t *latencyTracker) record(op string, d time.Duration) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.windows[op]
+	if !ok {
+		w = &latencyWindow{samples: make([]time.Duration, defaultLatencyWindow)}
+		t.windows[op] = w
+	}
+	w.add(d)
+	return w.p95()
+}
+
+func (t *latencyTracker) timeoutFor(op string) time.Duration {
+	if t.adaptive == nil {
+		return 0
+	}
+	p95, n := t.P95(op)
+	if n < t.adaptive.MinSamples {
+		return 0
+	}
+	timeout := time.Duration(float64(p95) * t.adaptive.Multiplier)
+	if t.adaptive.Min > 0 && timeout < t.adaptive.Min {
+		timeout = t.adaptive.Min
+	}
+	if t.adaptive.Max > 0 && timeout > t.adaptive.Max {
+		timeout = t.adaptive.Max
+	}
+	return timeout
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *latencyTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Event streams are long-lived; timing them would skew the percentiles.
+	if req.Header.Get("Accept") == "text/event-stream" {
+		return t.base.RoundTrip(req)
+	}
+
+	op := operationName(req)
+	timeout := t.timeoutFor(op)
+
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		if deadline, ok := req.Context().Deadline(); !ok || time.Until(deadline) > timeout {
+			var ctx context.Context
+			ctx, cancel = context.WithTimeout(req.Context(), timeout)
+			req = req.WithContext(ctx)
+		} else {
+			timeout = 0
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	p95 := t.record(op, latency)
+	if t.hook != nil {
+		m := OperationMetrics{
+			Operation: op,
+			Latency:   latency,
+			Err:       err,
+			P95:       p95,
+			Timeout:   timeout,
+		}
+		if resp != nil {
+			m.StatusCode = resp.StatusCode
+		}
+		t.hook(m)
+	}
+
+	if cancel != nil {
+		if err != nil {
+			cancel()
+		} else {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		}
+	}
+	return resp, err
+}
+
+// cancelOnClose releases a request context once the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// LatencyP95 returns the rolling p95 latency observed for an operation such
+// as "POST /memory/query".
+func (c *Client) LatencyP95(operation string) time.Duration {
+	p95, _ := c.tracker.P95(operation)
+	return p95
+}
+
+// LatencySnapshot returns the rolling p95 latency of every operation seen so far.
+func (c *Client) LatencySnapshot() map[string]time.Duration {
+	return c.tracker.snapshot()
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -31,6 +33,18 @@ const (
 	ConsolidationDormant      ConsolidationState = "dormant"
 )
 
+// MergeStrategy controls how memory contents are combined by Merge.
+type MergeStrategy string
+
+const (
+	// MergeKeepTarget keeps the target's content unchanged.
+	MergeKeepTarget MergeStrategy = "keep_target"
+	// MergeConcatenate appends source contents to the target's content.
+	MergeConcatenate MergeStrategy = "concatenate"
+	// MergeSummarize replaces the content with a server-generated summary.
+	MergeSummarize MergeStrategy = "summarize"
+)
+
 // Memory represents a memory from the Trace Manifold.
 type Memory struct {
 	ID                 string                 `json:"id"`
@@ -46,51 +60,145 @@ type Memory struct {
 	AccessCount        int                    `json:"accessCount"`
 	Embedding          []float64              `json:"embedding,omitempty"`
 	Metadata           map[string]interface{} `json:"metadata"`
+	ExpiresAt          time.Time              `json:"expiresAt,omitempty"`
+	Provenance         *Provenance            `json:"provenance,omitempty"`
+	// Version changes on every mutation; pass it as IfMatch for safe updates.
+	Version string `json:"version"`
+	// Attachments are Forge Bridge VFS paths linked to the memory.
+	Attachments []string `json:"attachments,omitempty"`
+	// Archived is true if the memory has been moved to cold storage.
+	Archived bool `json:"archived,omitempty"`
+	// Stale is true if Get served the memory from a LocalCache because the
+	// Memory Service was unreachable; CachedAt is when it was cached.
+	Stale    bool      `json:"stale,omitempty"`
+	CachedAt time.Time `json:"cachedAt,omitempty"`
 }
 
 // MemoryQueryResult represents a memory with its relevance score.
 type MemoryQueryResult struct {
 	Memory    Memory  `json:"memory"`
 	Relevance float64 `json:"relevance"`
+	// Stale is true if the result was served from a LocalCache because the
+	// Memory Service was unreachable; CachedAt is when it was cached.
+	Stale    bool      `json:"stale,omitempty"`
+	CachedAt time.Time `json:"cachedAt,omitempty"`
+	// Explanation is set when QueryRequest.Explain is true.
+	Explanation *ScoreExplanation `json:"explanation,omitempty"`
+}
+
+// ScoreExplanation breaks a query result's relevance down into the
+// contributions of each scoring signal.
+type ScoreExplanation struct {
+	VectorSimilarity     float64 `json:"vectorSimilarity"`
+	RecencyBoost         float64 `json:"recencyBoost"`
+	StrengthContribution float64 `json:"strengthContribution"`
+	ImportanceBoost      float64 `json:"importanceBoost"`
+	// Details holds any additional server-specific signals.
+	Details map[string]float64 `json:"details,omitempty"`
 }
 
+// Relationship describes how two memories connected by an edge relate.
+type Relationship string
+
+const (
+	RelCauses      Relationship = "causes"
+	RelContradicts Relationship = "contradicts"
+	RelFollows     Relationship = "follows"
+	RelSupports    Relationship = "supports"
+	RelDerivedFrom Relationship = "derived_from"
+	RelPartOf      Relationship = "part_of"
+	RelSimilarTo   Relationship = "similar_to"
+	RelRelatedTo   Relationship = "related_to"
+)
+
 // Edge represents an edge between two memories.
 type Edge struct {
-	SourceID           string    `json:"sourceId"`
-	TargetID           string    `json:"targetId"`
-	Relationship       string    `json:"relationship"`
-	Strength           float64   `json:"strength"`
-	CreatedAt          time.Time `json:"createdAt"`
-	LastStrengthenedAt time.Time `json:"lastStrengthenedAt"`
+	SourceID           string                 `json:"sourceId"`
+	TargetID           string                 `json:"targetId"`
+	Relationship       Relationship           `json:"relationship"`
+	Strength           float64                `json:"strength"`
+	CreatedAt          time.Time              `json:"createdAt"`
+	LastStrengthenedAt time.Time              `json:"lastStrengthenedAt"`
+	Metadata           map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // RecordRequest represents a request to record a memory.
 type RecordRequest struct {
 	Content    string                 `json:"content"`
 	MemoryType MemoryType             `json:"memoryType"`
-	Importance float64                `json:"importance"`
+	Importance *float64               `json:"importance,omitempty"`
 	Namespace  string                 `json:"namespace"`
 	Tags       []string               `json:"tags"`
 	Metadata   map[string]interface{} `json:"metadata"`
+	// ExpiresAt marks the memory as transient; the server purges it at this time.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// TTLSeconds is an alternative to ExpiresAt relative to the time of recording.
+	TTLSeconds int `json:"ttlSeconds,omitempty"`
+	// Provenance records where the memory came from.
+	Provenance *Provenance `json:"provenance,omitempty"`
+	// Attachments are Forge Bridge VFS paths holding large payloads related
+	// to the memory, so they need not be stuffed into Content.
+	Attachments []string `json:"attachments,omitempty"`
 }
 
 // QueryRequest represents a request to query memories.
 type QueryRequest struct {
 	Query        string       `json:"query"`
-	Limit        int          `json:"limit"`
-	MinRelevance float64      `json:"minRelevance"`
+	Limit        *int         `json:"limit,omitempty"`
+	MinRelevance *float64     `json:"minRelevance,omitempty"`
 	MemoryTypes  []MemoryType `json:"memoryTypes,omitempty"`
 	Namespace    string       `json:"namespace,omitempty"`
 	Tags         []string     `json:"tags,omitempty"`
+	// Provenance restricts results to memories with matching provenance.
+	Provenance *ProvenanceFilter `json:"provenance,omitempty"`
+	// IncludeEmbedding returns each result's embedding; omitted by default.
+	IncludeEmbedding bool `json:"includeEmbedding,omitempty"`
+	// Embedding queries by vector instead of by Query text.
+	Embedding []float64 `json:"embedding,omitempty"`
+	// Explain returns a per-result scoring breakdown.
+	Explain bool `json:"explain,omitempty"`
+	// IncludeArchived also searches memories moved to cold storage by Archive.
+	IncludeArchived bool `json:"includeArchived,omitempty"`
+}
+
+// CountRequest represents a request to count memories matching filters.
+type CountRequest struct {
+	MemoryTypes []MemoryType      `json:"memoryTypes,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Provenance  *ProvenanceFilter `json:"provenance,omitempty"`
 }
 
 // MemoryClient provides access to the Memory Service API.
 type MemoryClient struct {
-	baseURL       string
-	apiKey        string
-	agentID       string
-	authenticator *PersonaAuthenticator
-	httpClient    *http.Client
+	baseURL           string
+	apiKey            string
+	agentID           string
+	authenticator     *PersonaAuthenticator
+	httpClient        *http.Client
+	strictConcurrency bool
+	defaults          MemoryDefaults
+	namespace         string
+	cache             *LocalCache
+	guard             *Guard
+}
+
+// MemoryOption is a function that configures a MemoryClient
+type MemoryOption func(*MemoryClient)
+
+// WithStrictConcurrency requires an IfMatch version on every Update, Delete,
+// Merge and CreateEdge so concurrent writers cannot silently overwrite each
+// other.
+func WithStrictConcurrency() MemoryOption {
+	return func(c *MemoryClient) {
+		c.strictConcurrency = true
+	}
+}
+
+func withMemoryTransport(rt http.RoundTripper) MemoryOption {
+	return func(c *MemoryClient) {
+		c.httpClient.Transport = rt
+	}
 }
 
 // NewMemoryClient creates a new Memory Service client.
@@ -98,8 +206,9 @@ func NewMemoryClient(
 	baseURL, apiKey, agentID string,
 	auth *PersonaAuthenticator,
 	timeoutSeconds int,
+	opts ...MemoryOption,
 ) *MemoryClient {
-	return &MemoryClient{
+	c := &MemoryClient{
 		baseURL:       baseURL + "/v1/memory",
 		apiKey:        apiKey,
 		agentID:       agentID,
@@ -107,10 +216,19 @@ func NewMemoryClient(
 		httpClient: &http.Client{
 			Timeout: time.Duration(timeoutSeconds) * time.Second,
 		},
+		defaults: builtinMemoryDefaults(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func (c *MemoryClient) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.doRequestWithHeaders(ctx, method, path, body, nil)
+}
+
+func (c *MemoryClient) doRequestWithHeaders(ctx context.Context, method, path string, body interface{}, headers map[string]string) (*http.Response, error) {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -128,7 +246,7 @@ func (c *MemoryClient) doRequest(ctx context.Context, method, path string, body
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-API-Key", c.apiKey)
 	req.Header.Set("X-Agent-ID", c.agentID)
-	req.Header.Set("User-Agent", "bravozero-go/1.0.0")
+	req.Header.Set("User-Agent", "bravozero-go/"+Version)
 
 	if c.authenticator != nil {
 		attestation, err := c.authenticator.CreateAttestation("")
@@ -138,6 +256,14 @@ func (c *MemoryClient) doRequest(ctx context.Context, method, path string, body
 		req.Header.Set("X-Persona-Attestation", attestation)
 	}
 
+	if token := executionTokenFromContext(ctx); token != "" {
+		req.Header.Set("X-Execution-Token", token)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
@@ -148,10 +274,16 @@ func (c *MemoryClient) doRequest(ctx context.Context, method, path string, body
 		return nil, &RateLimitError{RetryAfter: 60}
 	}
 
+	if resp.StatusCode == 409 || resp.StatusCode == 412 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, parseConflictError(body)
+	}
+
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return resp, nil
@@ -159,14 +291,11 @@ func (c *MemoryClient) doRequest(ctx context.Context, method, path string, body
 
 // Record records a new memory to the Trace Manifold.
 func (c *MemoryClient) Record(ctx context.Context, req RecordRequest) (*Memory, error) {
-	if req.MemoryType == "" {
-		req.MemoryType = MemoryTypeSemantic
-	}
-	if req.Importance == 0 {
-		req.Importance = 0.5
-	}
-	if req.Namespace == "" {
-		req.Namespace = c.agentID
+	c.applyRecordDefaults(&req)
+
+	ctx, err := c.guard.check(ctx, "memory.record", map[string]interface{}{"namespace": req.Namespace, "memoryType": req.MemoryType})
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := c.doRequest(ctx, "POST", "/record", req)
@@ -178,29 +307,90 @@ func (c *MemoryClient) Record(ctx context.Context, req RecordRequest) (*Memory,
 	return c.parseMemory(resp.Body)
 }
 
-// Query queries memories by semantic similarity.
-func (c *MemoryClient) Query(ctx context.Context, req QueryRequest) ([]MemoryQueryResult, error) {
-	if req.Limit == 0 {
-		req.Limit = 10
+// RecordBatch records several memories in one round trip. The returned
+// memories are in the same order as reqs.
+func (c *MemoryClient) RecordBatch(ctx context.Context, reqs []RecordRequest) ([]Memory, error) {
+	return c.recordBatch(ctx, reqs, "")
+}
+
+// recordBatch is RecordBatch sending idempotencyKey, if set, as the
+// Idempotency-Key header, so the service stores a batch that is sent again
+// after a lost response only once.
+func (c *MemoryClient) recordBatch(ctx context.Context, reqs []RecordRequest, idempotencyKey string) ([]Memory, error) {
+	if len(reqs) == 0 {
+		return []Memory{}, nil
+	}
+
+	batch := make([]RecordRequest, len(reqs))
+	for i, req := range reqs {
+		c.applyRecordDefaults(&req)
+		batch[i] = req
+	}
+
+	body := map[string]interface{}{"memories": batch}
+
+	ctx, err := c.guard.check(ctx, "memory.record", map[string]interface{}{"count": len(batch)})
+	if err != nil {
+		return nil, err
+	}
+
+	var headers map[string]string
+	if idempotencyKey != "" {
+		headers = map[string]string{"Idempotency-Key": idempotencyKey}
 	}
-	if req.MinRelevance == 0 {
-		req.MinRelevance = 0.5
+
+	resp, err := c.doRequestWithHeaders(ctx, "POST", "/batch/record", body, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Memories []json.RawMessage `json:"memories"`
 	}
 
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	memories := make([]Memory, len(data.Memories))
+	for i, raw := range data.Memories {
+		memory, err := c.parseMemoryBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		memories[i] = *memory
+	}
+
+	return memories, nil
+}
+
+// Query queries memories by semantic similarity.
+func (c *MemoryClient) Query(ctx context.Context, req QueryRequest) ([]MemoryQueryResult, error) {
+	c.applyQueryDefaults(&req)
+
 	resp, err := c.doRequest(ctx, "POST", "/query", req)
 	if err != nil {
+		if c.cache != nil && isUnreachable(ctx, err) {
+			return c.cache.query(req), nil
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	return c.decodeQueryResults(resp.Body)
+}
+
+func (c *MemoryClient) decodeQueryResults(r io.Reader) ([]MemoryQueryResult, error) {
 	var data struct {
 		Results []struct {
-			Memory    json.RawMessage `json:"memory"`
-			Relevance float64         `json:"relevance"`
+			Memory      json.RawMessage   `json:"memory"`
+			Relevance   float64           `json:"relevance"`
+			Explanation *ScoreExplanation `json:"explanation"`
 		} `json:"results"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -211,18 +401,65 @@ func (c *MemoryClient) Query(ctx context.Context, req QueryRequest) ([]MemoryQue
 			return nil, err
 		}
 		results[i] = MemoryQueryResult{
-			Memory:    *memory,
-			Relevance: r.Relevance,
+			Memory:      *memory,
+			Relevance:   r.Relevance,
+			Explanation: r.Explanation,
 		}
 	}
 
 	return results, nil
 }
 
+// Count returns the number of memories matching the request filters.
+func (c *MemoryClient) Count(ctx context.Context, req CountRequest) (int, error) {
+	req.Namespace = c.scopedNamespace(req.Namespace)
+
+	resp, err := c.doRequest(ctx, "POST", "/count", req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Count int `json:"count"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.Count, nil
+}
+
+// GetOption configures a Get call.
+type GetOption func(url.Values)
+
+// WithEmbedding includes the memory's embedding in the response. Embeddings
+// are omitted by default to keep payloads small.
+func WithEmbedding() GetOption {
+	return func(v url.Values) {
+		v.Set("includeEmbedding", "true")
+	}
+}
+
 // Get retrieves a specific memory by ID.
-func (c *MemoryClient) Get(ctx context.Context, memoryID string) (*Memory, error) {
-	resp, err := c.doRequest(ctx, "GET", "/"+memoryID, nil)
+func (c *MemoryClient) Get(ctx context.Context, memoryID string, opts ...GetOption) (*Memory, error) {
+	path := "/" + memoryID
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
+		if c.cache != nil && isUnreachable(ctx, err) {
+			if memory, ok := c.cache.get(memoryID); ok {
+				return &memory, nil
+			}
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -230,18 +467,189 @@ func (c *MemoryClient) Get(ctx context.Context, memoryID string) (*Memory, error
 	return c.parseMemory(resp.Body)
 }
 
-// Delete deletes a memory.
+// GetEmbedding retrieves only the embedding vector of a memory.
+func (c *MemoryClient) GetEmbedding(ctx context.Context, memoryID string) ([]float64, error) {
+	resp, err := c.doRequest(ctx, "GET", "/"+memoryID+"/embedding", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Embedding []float64 `json:"embedding"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.Embedding, nil
+}
+
+// GetMany retrieves several memories in one round trip. Memories are returned
+// in the order of ids; IDs that do not exist are omitted.
+func (c *MemoryClient) GetMany(ctx context.Context, ids []string) ([]Memory, error) {
+	if len(ids) == 0 {
+		return []Memory{}, nil
+	}
+
+	body := map[string]interface{}{"ids": ids}
+
+	resp, err := c.doRequest(ctx, "POST", "/batch/get", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Memories []json.RawMessage `json:"memories"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	byID := make(map[string]Memory, len(data.Memories))
+	for _, raw := range data.Memories {
+		memory, err := c.parseMemoryBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		byID[memory.ID] = *memory
+	}
+
+	memories := make([]Memory, 0, len(byID))
+	for _, id := range ids {
+		if memory, ok := byID[id]; ok {
+			memories = append(memories, memory)
+		}
+	}
+
+	return memories, nil
+}
+
+// Delete deletes a memory. In strict concurrency mode use DeleteIfMatch instead.
 func (c *MemoryClient) Delete(ctx context.Context, memoryID string) error {
+	if c.strictConcurrency {
+		return fmt.Errorf("IfMatch version required in strict concurrency mode")
+	}
+
+	ctx, err := c.guard.check(ctx, "memory.delete", map[string]interface{}{"memoryId": memoryID})
+	if err != nil {
+		return err
+	}
+
 	resp, err := c.doRequest(ctx, "DELETE", "/"+memoryID, nil)
 	if err != nil {
 		return err
 	}
 	resp.Body.Close()
+	c.cache.remove(memoryID)
+	return nil
+}
+
+// UpdateRequest represents a request to update an existing memory.
+// Zero-valued fields are left unchanged.
+type UpdateRequest struct {
+	Content    string                 `json:"content,omitempty"`
+	Importance *float64               `json:"importance,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	// IfMatch is the memory Version the update is based on. The update fails
+	// with a ConflictError if the memory has changed since.
+	IfMatch string `json:"-"`
+}
+
+// Update modifies an existing memory.
+func (c *MemoryClient) Update(ctx context.Context, memoryID string, req UpdateRequest) (*Memory, error) {
+	headers, err := c.ifMatchHeaders(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = c.guard.check(ctx, "memory.update", map[string]interface{}{"memoryId": memoryID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, "PATCH", "/"+memoryID, req, headers)
+	if err != nil {
+		return nil, withConflictID(err, memoryID)
+	}
+	defer resp.Body.Close()
+
+	return c.parseMemory(resp.Body)
+}
+
+// DeleteIfMatch deletes a memory only if its current Version equals version.
+func (c *MemoryClient) DeleteIfMatch(ctx context.Context, memoryID, version string) error {
+	headers, err := c.ifMatchHeaders(version)
+	if err != nil {
+		return err
+	}
+	ctx, err = c.guard.check(ctx, "memory.delete", map[string]interface{}{"memoryId": memoryID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, "DELETE", "/"+memoryID, nil, headers)
+	if err != nil {
+		return withConflictID(err, memoryID)
+	}
+	resp.Body.Close()
+	c.cache.remove(memoryID)
 	return nil
 }
 
-// CreateEdge creates an edge between two memories.
-func (c *MemoryClient) CreateEdge(ctx context.Context, sourceID, targetID, relationship string, strength float64) (*Edge, error) {
+func (c *MemoryClient) ifMatchHeaders(version string) (map[string]string, error) {
+	if version == "" {
+		if c.strictConcurrency {
+			return nil, fmt.Errorf("IfMatch version required in strict concurrency mode")
+		}
+		return nil, nil
+	}
+	return map[string]string{"If-Match": version}, nil
+}
+
+// EdgeOption configures CreateEdge.
+type EdgeOption func(*edgeConfig)
+
+type edgeConfig struct {
+	metadata map[string]interface{}
+	ifMatch  string
+}
+
+// WithEdgeMetadata attaches metadata to the created edge.
+func WithEdgeMetadata(metadata map[string]interface{}) EdgeOption {
+	return func(c *edgeConfig) {
+		c.metadata = metadata
+	}
+}
+
+// WithEdgeIfMatch only creates the edge if the source memory's current
+// Version equals version. It is required in strict concurrency mode.
+func WithEdgeIfMatch(version string) EdgeOption {
+	return func(c *edgeConfig) {
+		c.ifMatch = version
+	}
+}
+
+// CreateEdge creates an edge between two memories. relationship is usually
+// one of the Rel constants, e.g. RelCauses.
+func (c *MemoryClient) CreateEdge(ctx context.Context, sourceID, targetID string, relationship Relationship, strength float64, opts ...EdgeOption) (*Edge, error) {
+	cfg := edgeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	headers, err := c.ifMatchHeaders(cfg.ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = c.guard.check(ctx, "memory.create_edge", map[string]interface{}{"sourceId": sourceID, "targetId": targetID, "relationship": relationship})
+	if err != nil {
+		return nil, err
+	}
+
 	if strength == 0 {
 		strength = 0.5
 	}
@@ -252,37 +660,267 @@ func (c *MemoryClient) CreateEdge(ctx context.Context, sourceID, targetID, relat
 		"relationship": relationship,
 		"strength":     strength,
 	}
+	if cfg.metadata != nil {
+		body["metadata"] = cfg.metadata
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, "POST", "/edges", body, headers)
+	if err != nil {
+		return nil, withConflictID(err, sourceID)
+	}
+	defer resp.Body.Close()
+
+	var data rawEdge
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.toEdge(), nil
+}
+
+type rawEdge struct {
+	SourceID           string                 `json:"sourceId"`
+	TargetID           string                 `json:"targetId"`
+	Relationship       string                 `json:"relationship"`
+	Strength           float64                `json:"strength"`
+	CreatedAt          string                 `json:"createdAt"`
+	LastStrengthenedAt string                 `json:"lastStrengthenedAt"`
+	Metadata           map[string]interface{} `json:"metadata"`
+}
+
+func (r rawEdge) toEdge() *Edge {
+	createdAt, _ := time.Parse(time.RFC3339, r.CreatedAt)
+	lastStrengthened, _ := time.Parse(time.RFC3339, r.LastStrengthenedAt)
+
+	return &Edge{
+		SourceID:           r.SourceID,
+		TargetID:           r.TargetID,
+		Relationship:       Relationship(r.Relationship),
+		Strength:           r.Strength,
+		CreatedAt:          createdAt,
+		LastStrengthenedAt: lastStrengthened,
+		Metadata:           r.Metadata,
+	}
+}
+
+// Similarity returns the cosine similarity of two memories' stored embeddings,
+// computed server-side.
+func (c *MemoryClient) Similarity(ctx context.Context, memoryIDA, memoryIDB string) (float64, error) {
+	params := url.Values{}
+	params.Set("a", memoryIDA)
+	params.Set("b", memoryIDB)
+
+	resp, err := c.doRequest(ctx, "GET", "/similarity?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Similarity float64 `json:"similarity"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.Similarity, nil
+}
+
+// FindSimilar returns up to limit memories most similar to an existing
+// memory, excluding the memory itself.
+func (c *MemoryClient) FindSimilar(ctx context.Context, memoryID string, limit int) ([]MemoryQueryResult, error) {
+	path := "/" + memoryID + "/similar"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return c.decodeQueryResults(resp.Body)
+}
+
+// DecayPrediction describes a memory that will change consolidation state
+// within a preview horizon.
+type DecayPrediction struct {
+	Memory            Memory             `json:"memory"`
+	PredictedState    ConsolidationState `json:"predictedState"`
+	TransitionAt      time.Time          `json:"transitionAt"`
+	ProjectedStrength float64            `json:"projectedStrength"`
+}
+
+// PreviewDecay returns the memories in namespace that would transition to
+// decaying or dormant within horizon, so they can be pinned or reinforced.
+func (c *MemoryClient) PreviewDecay(ctx context.Context, namespace string, horizon time.Duration) ([]DecayPrediction, error) {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
 
-	resp, err := c.doRequest(ctx, "POST", "/edges", body)
+	params := url.Values{}
+	params.Set("namespace", namespace)
+	params.Set("horizonSeconds", strconv.FormatInt(int64(horizon/time.Second), 10))
+
+	resp, err := c.doRequest(ctx, "GET", "/decay/preview?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	var data struct {
-		SourceID           string `json:"sourceId"`
-		TargetID           string `json:"targetId"`
-		Relationship       string `json:"relationship"`
-		Strength           float64 `json:"strength"`
-		CreatedAt          string `json:"createdAt"`
-		LastStrengthenedAt string `json:"lastStrengthenedAt"`
+		Predictions []struct {
+			Memory            json.RawMessage `json:"memory"`
+			PredictedState    string          `json:"predictedState"`
+			TransitionAt      string          `json:"transitionAt"`
+			ProjectedStrength float64         `json:"projectedStrength"`
+		} `json:"predictions"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, data.CreatedAt)
-	lastStrengthened, _ := time.Parse(time.RFC3339, data.LastStrengthenedAt)
+	predictions := make([]DecayPrediction, len(data.Predictions))
+	for i, p := range data.Predictions {
+		memory, err := c.parseMemoryBytes(p.Memory)
+		if err != nil {
+			return nil, err
+		}
+		transitionAt, _ := time.Parse(time.RFC3339, p.TransitionAt)
+		predictions[i] = DecayPrediction{
+			Memory:            *memory,
+			PredictedState:    ConsolidationState(p.PredictedState),
+			TransitionAt:      transitionAt,
+			ProjectedStrength: p.ProjectedStrength,
+		}
+	}
 
-	return &Edge{
-		SourceID:           data.SourceID,
-		TargetID:           data.TargetID,
-		Relationship:       data.Relationship,
-		Strength:           data.Strength,
-		CreatedAt:          createdAt,
-		LastStrengthenedAt: lastStrengthened,
-	}, nil
+	return predictions, nil
+}
+
+// ConvertType changes a memory's type, e.g. promoting a working memory to
+// episodic or a consolidated episodic memory to semantic.
+func (c *MemoryClient) ConvertType(ctx context.Context, memoryID string, newType MemoryType) (*Memory, error) {
+	ctx, err := c.guard.check(ctx, "memory.convert_type", map[string]interface{}{"memoryId": memoryID, "memoryType": newType})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{"memoryType": newType}
+
+	resp, err := c.doRequest(ctx, "POST", "/"+memoryID+"/convert", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return c.parseMemory(resp.Body)
+}
+
+// MemoryPath is a chain of memories connected by edges. Edges[i] connects
+// Memories[i] and Memories[i+1].
+type MemoryPath struct {
+	Memories []Memory `json:"memories"`
+	Edges    []Edge   `json:"edges"`
+}
+
+// FindPath finds the shortest chain of edges connecting two memories, following
+// at most maxDepth edges. An empty path is returned if none exists.
+func (c *MemoryClient) FindPath(ctx context.Context, sourceID, targetID string, maxDepth int) (*MemoryPath, error) {
+	params := url.Values{}
+	params.Set("source", sourceID)
+	params.Set("target", targetID)
+	if maxDepth > 0 {
+		params.Set("maxDepth", strconv.Itoa(maxDepth))
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/path?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Memories []json.RawMessage `json:"memories"`
+		Edges    []rawEdge         `json:"edges"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	path := &MemoryPath{
+		Memories: make([]Memory, len(data.Memories)),
+		Edges:    make([]Edge, len(data.Edges)),
+	}
+	for i, m := range data.Memories {
+		memory, err := c.parseMemoryBytes(m)
+		if err != nil {
+			return nil, err
+		}
+		path.Memories[i] = *memory
+	}
+	for i, e := range data.Edges {
+		path.Edges[i] = *e.toEdge()
+	}
+
+	return path, nil
+}
+
+// MergeOption configures Merge.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	ifMatch string
+}
+
+// WithMergeIfMatch only merges if the target memory's current Version equals
+// version. It is required in strict concurrency mode.
+func WithMergeIfMatch(version string) MergeOption {
+	return func(c *mergeConfig) {
+		c.ifMatch = version
+	}
+}
+
+// Merge combines the source memories into the target memory. Tags and metadata
+// are unioned, edges pointing at the sources are rewired to the target, and
+// the sources are deleted. The merged target memory is returned.
+func (c *MemoryClient) Merge(ctx context.Context, targetID string, sourceIDs []string, strategy MergeStrategy, opts ...MergeOption) (*Memory, error) {
+	cfg := mergeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	headers, err := c.ifMatchHeaders(cfg.ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = c.guard.check(ctx, "memory.merge", map[string]interface{}{"targetId": targetID, "sourceIds": sourceIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	if strategy == "" {
+		strategy = MergeKeepTarget
+	}
+
+	body := map[string]interface{}{
+		"targetId":  targetID,
+		"sourceIds": sourceIDs,
+		"strategy":  strategy,
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, "POST", "/merge", body, headers)
+	if err != nil {
+		return nil, withConflictID(err, targetID)
+	}
+	defer resp.Body.Close()
+
+	return c.parseMemory(resp.Body)
 }
 
 func (c *MemoryClient) parseMemory(r io.Reader) (*Memory, error) {
@@ -308,6 +946,11 @@ func (c *MemoryClient) parseMemoryBytes(data []byte) (*Memory, error) {
 		AccessCount        int                    `json:"accessCount"`
 		Embedding          []float64              `json:"embedding"`
 		Metadata           map[string]interface{} `json:"metadata"`
+		ExpiresAt          string                 `json:"expiresAt"`
+		Provenance         *Provenance            `json:"provenance"`
+		Version            string                 `json:"version"`
+		Attachments        []string               `json:"attachments"`
+		Archived           bool                   `json:"archived"`
 	}
 
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -316,8 +959,9 @@ func (c *MemoryClient) parseMemoryBytes(data []byte) (*Memory, error) {
 
 	createdAt, _ := time.Parse(time.RFC3339, raw.CreatedAt)
 	lastAccessed, _ := time.Parse(time.RFC3339, raw.LastAccessedAt)
+	expiresAt, _ := time.Parse(time.RFC3339, raw.ExpiresAt)
 
-	return &Memory{
+	memory := &Memory{
 		ID:                 raw.ID,
 		Content:            raw.Content,
 		MemoryType:         MemoryType(raw.MemoryType),
@@ -331,5 +975,13 @@ func (c *MemoryClient) parseMemoryBytes(data []byte) (*Memory, error) {
 		AccessCount:        raw.AccessCount,
 		Embedding:          raw.Embedding,
 		Metadata:           raw.Metadata,
-	}, nil
+		ExpiresAt:          expiresAt,
+		Provenance:         raw.Provenance,
+		Version:            raw.Version,
+		Attachments:        raw.Attachments,
+		Archived:           raw.Archived,
+	}
+	c.cache.put(*memory)
+
+	return memory, nil
 }
@@ -0,0 +1,50 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AccessRecord is a single past retrieval of a memory.
+type AccessRecord struct {
+	AgentID    string    `json:"agentId"`
+	AccessedAt time.Time `json:"accessedAt"`
+	// Operation is the API that returned the memory, e.g. "get" or "query".
+	Operation string `json:"operation"`
+}
+
+// AccessHistory returns past retrievals of a memory, most recent first, so
+// consumers of a memory can be audited.
+func (c *MemoryClient) AccessHistory(ctx context.Context, memoryID string) ([]AccessRecord, error) {
+	resp, err := c.doRequest(ctx, "GET", "/"+memoryID+"/access-history", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Accesses []struct {
+			AgentID    string `json:"agentId"`
+			AccessedAt string `json:"accessedAt"`
+			Operation  string `json:"operation"`
+		} `json:"accesses"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	records := make([]AccessRecord, len(data.Accesses))
+	for i, a := range data.Accesses {
+		accessedAt, _ := time.Parse(time.RFC3339, a.AccessedAt)
+		records[i] = AccessRecord{
+			AgentID:    a.AgentID,
+			AccessedAt: accessedAt,
+			Operation:  a.Operation,
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,93 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ArchiveFilter selects memories to move to cold storage. Empty fields match
+// all memories; at least one field must be set.
+type ArchiveFilter struct {
+	Namespace   string               `json:"namespace,omitempty"`
+	MemoryTypes []MemoryType         `json:"memoryTypes,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	States      []ConsolidationState `json:"states,omitempty"`
+	// NotAccessedSince matches memories last accessed before this time.
+	NotAccessedSince *time.Time `json:"notAccessedSince,omitempty"`
+	// MaxStrength matches memories whose strength is at most this value.
+	MaxStrength *float64 `json:"maxStrength,omitempty"`
+}
+
+// Archive moves the memories matching filter to the cold-storage tier and
+// returns their IDs. Archived memories are excluded from queries unless
+// QueryRequest.IncludeArchived is set, and remain readable with Get.
+func (c *MemoryClient) Archive(ctx context.Context, filter ArchiveFilter) ([]string, error) {
+	if filter.Namespace == "" && len(filter.MemoryTypes) == 0 && len(filter.Tags) == 0 &&
+		len(filter.States) == 0 && filter.NotAccessedSince == nil && filter.MaxStrength == nil {
+		return nil, fmt.Errorf("archive filter must not be empty")
+	}
+	filter.Namespace = c.scopedNamespace(filter.Namespace)
+
+	ctx, err := c.guard.check(ctx, "memory.archive", map[string]interface{}{"filter": filter})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/archive", filter)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		ArchivedIDs []string `json:"archivedIds"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, id := range data.ArchivedIDs {
+		c.cache.remove(id)
+	}
+
+	return data.ArchivedIDs, nil
+}
+
+// Unarchive moves the given memories back from cold storage so they are
+// included in queries again. It returns the restored memories.
+func (c *MemoryClient) Unarchive(ctx context.Context, ids []string) ([]Memory, error) {
+	ctx, err := c.guard.check(ctx, "memory.unarchive", map[string]interface{}{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string][]string{"ids": ids}
+
+	resp, err := c.doRequest(ctx, "POST", "/unarchive", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Memories []json.RawMessage `json:"memories"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	memories := make([]Memory, len(data.Memories))
+	for i, raw := range data.Memories {
+		memory, err := c.parseMemoryBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		memories[i] = *memory
+	}
+
+	return memories, nil
+}
@@ -0,0 +1,63 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Attachment is a Forge Bridge file linked to a memory.
+type Attachment struct {
+	Path string `json:"path"`
+	// File is nil if the attachment no longer exists in the VFS.
+	File *FileInfo `json:"file,omitempty"`
+}
+
+// ResolveAttachments returns the current VFS metadata of each attachment of
+// a memory. Read the contents with the Bridge client.
+func (c *MemoryClient) ResolveAttachments(ctx context.Context, memoryID string) ([]Attachment, error) {
+	resp, err := c.doRequest(ctx, "GET", "/"+memoryID+"/attachments", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Attachments []struct {
+			Path string       `json:"path"`
+			File *rawFileInfo `json:"file"`
+		} `json:"attachments"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	attachments := make([]Attachment, len(data.Attachments))
+	for i, a := range data.Attachments {
+		attachments[i] = Attachment{Path: a.Path}
+		if a.File != nil {
+			attachments[i].File = a.File.toFileInfo()
+		}
+	}
+
+	return attachments, nil
+}
+
+// Attach links additional VFS paths to an existing memory.
+func (c *MemoryClient) Attach(ctx context.Context, memoryID string, paths ...string) (*Memory, error) {
+	ctx, err := c.guard.check(ctx, "memory.attach", map[string]interface{}{"memoryId": memoryID, "paths": paths})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{"paths": paths}
+
+	resp, err := c.doRequest(ctx, "POST", "/"+memoryID+"/attachments", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return c.parseMemory(resp.Body)
+}
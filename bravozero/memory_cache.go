@@ -0,0 +1,217 @@
+package bravozero
+
+import (
+	"container/list"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocalCache is an in-memory, size-bounded mirror of recently recorded and
+// retrieved memories. When attached with WithLocalCache, it answers Get and
+// Query while the Memory Service is unreachable. Memories and query results
+// served from the cache are flagged Stale.
+type LocalCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type cachedMemory struct {
+	memory   Memory
+	cachedAt time.Time
+}
+
+// NewLocalCache creates a cache holding at most capacity memories, evicting
+// the least recently used.
+func NewLocalCache(capacity int) *LocalCache {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &LocalCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// WithLocalCache mirrors memories into cache and falls back to it when the
+// Memory Service cannot be reached.
+func WithLocalCache(cache *LocalCache) MemoryOption {
+	return func(c *MemoryClient) {
+		c.cache = cache
+	}
+}
+
+// Len returns the number of cached memories.
+func (lc *LocalCache) Len() int {
+	if lc == nil {
+		return 0
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.order.Len()
+}
+
+func (lc *LocalCache) put(m Memory) {
+	if lc == nil || m.ID == "" {
+		return
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if el, ok := lc.entries[m.ID]; ok {
+		entry := el.Value.(*cachedMemory)
+		// Keep a previously cached embedding if this copy was fetched without one.
+		if m.Embedding == nil {
+			m.Embedding = entry.memory.Embedding
+		}
+		entry.memory = m
+		entry.cachedAt = time.Now()
+		lc.order.MoveToFront(el)
+		return
+	}
+
+	lc.entries[m.ID] = lc.order.PushFront(&cachedMemory{memory: m, cachedAt: time.Now()})
+	for lc.order.Len() > lc.capacity {
+		oldest := lc.order.Back()
+		lc.order.Remove(oldest)
+		delete(lc.entries, oldest.Value.(*cachedMemory).memory.ID)
+	}
+}
+
+func (lc *LocalCache) get(id string) (Memory, bool) {
+	if lc == nil {
+		return Memory{}, false
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	el, ok := lc.entries[id]
+	if !ok {
+		return Memory{}, false
+	}
+	lc.order.MoveToFront(el)
+	entry := el.Value.(*cachedMemory)
+	m := entry.memory
+	m.Stale = true
+	m.CachedAt = entry.cachedAt
+	return m, true
+}
+
+func (lc *LocalCache) remove(id string) {
+	if lc == nil {
+		return
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if el, ok := lc.entries[id]; ok {
+		lc.order.Remove(el)
+		delete(lc.entries, id)
+	}
+}
+
+// query scores cached memories against req. If req.Embedding is set and a
+// memory has an embedding, cosine similarity is used; otherwise the score is
+// the fraction of query terms found in the content.
+func (lc *LocalCache) query(req QueryRequest) []MemoryQueryResult {
+	if lc == nil {
+		return nil
+	}
+
+	terms := strings.Fields(strings.ToLower(req.Query))
+	types := make(map[MemoryType]bool, len(req.MemoryTypes))
+	for _, t := range req.MemoryTypes {
+		types[t] = true
+	}
+	minRelevance := ValueOr(req.MinRelevance, 0)
+
+	lc.mu.Lock()
+	var results []MemoryQueryResult
+	for el := lc.order.Front(); el != nil; el = el.Next() {
+		entry := el.Value.(*cachedMemory)
+		m := entry.memory
+		if req.Namespace != "" && m.Namespace != req.Namespace {
+			continue
+		}
+		if len(types) > 0 && !types[m.MemoryType] {
+			continue
+		}
+		if !hasAllTags(m.Tags, req.Tags) {
+			continue
+		}
+		if m.Archived && !req.IncludeArchived {
+			continue
+		}
+
+		var score float64
+		if len(req.Embedding) > 0 && len(m.Embedding) == len(req.Embedding) {
+			score = cosineSimilarity(req.Embedding, m.Embedding)
+		} else {
+			score = termOverlap(terms, strings.ToLower(m.Content))
+		}
+		if score < minRelevance {
+			continue
+		}
+		if !req.IncludeEmbedding {
+			m.Embedding = nil
+		}
+		results = append(results, MemoryQueryResult{
+			Memory:    m,
+			Relevance: score,
+			Stale:     true,
+			CachedAt:  entry.cachedAt,
+		})
+	}
+	lc.mu.Unlock()
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Relevance > results[j].Relevance })
+	if limit := ValueOr(req.Limit, 10); limit >= 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func hasAllTags(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func termOverlap(terms []string, content string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+	matched := 0
+	for _, t := range terms {
+		if strings.Contains(content, t) {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(terms))
+}
+
+func cosineSimilarity(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,68 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MemoryCluster is a group of related memories within a namespace.
+type MemoryCluster struct {
+	// Label is a short server-generated description of the topic.
+	Label string `json:"label"`
+	// Centroid is the mean embedding of the cluster's memories.
+	Centroid []float64 `json:"centroid,omitempty"`
+	// Representative is the memory closest to the centroid.
+	Representative Memory `json:"representative"`
+	// MemoryIDs lists every memory in the cluster.
+	MemoryIDs []string `json:"memoryIds"`
+}
+
+// Cluster groups the memories in namespace into k topic clusters. If k is
+// zero the server chooses the number of clusters.
+func (c *MemoryClient) Cluster(ctx context.Context, namespace string, k int) ([]MemoryCluster, error) {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
+
+	body := map[string]interface{}{"namespace": namespace}
+	if k > 0 {
+		body["k"] = k
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/clusters", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Clusters []struct {
+			Label          string          `json:"label"`
+			Centroid       []float64       `json:"centroid"`
+			Representative json.RawMessage `json:"representative"`
+			MemoryIDs      []string        `json:"memoryIds"`
+		} `json:"clusters"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	clusters := make([]MemoryCluster, len(data.Clusters))
+	for i, cl := range data.Clusters {
+		representative, err := c.parseMemoryBytes(cl.Representative)
+		if err != nil {
+			return nil, err
+		}
+		clusters[i] = MemoryCluster{
+			Label:          cl.Label,
+			Centroid:       cl.Centroid,
+			Representative: *representative,
+			MemoryIDs:      cl.MemoryIDs,
+		}
+	}
+
+	return clusters, nil
+}
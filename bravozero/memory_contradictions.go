@@ -0,0 +1,74 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ContradictionRequest scopes a contradiction search. Set MemoryID to find
+// memories contradicting one memory, or Namespace to scan a whole namespace.
+type ContradictionRequest struct {
+	Namespace     string  `json:"namespace,omitempty"`
+	MemoryID      string  `json:"memoryId,omitempty"`
+	MinConfidence float64 `json:"minConfidence,omitempty"`
+	Limit         int     `json:"limit,omitempty"`
+}
+
+// Contradiction is a pair of memories the service judges mutually inconsistent.
+type Contradiction struct {
+	A          Memory  `json:"a"`
+	B          Memory  `json:"b"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+}
+
+// FindContradictions returns pairs of mutually inconsistent memories, most
+// confident first, so agents can trigger reconciliation.
+func (c *MemoryClient) FindContradictions(ctx context.Context, req ContradictionRequest) ([]Contradiction, error) {
+	if req.MemoryID == "" {
+		req.Namespace = c.scopedNamespace(req.Namespace)
+		if req.Namespace == "" {
+			req.Namespace = c.agentID
+		}
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/contradictions", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Contradictions []struct {
+			A          json.RawMessage `json:"a"`
+			B          json.RawMessage `json:"b"`
+			Confidence float64         `json:"confidence"`
+			Reason     string          `json:"reason"`
+		} `json:"contradictions"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	contradictions := make([]Contradiction, len(data.Contradictions))
+	for i, ct := range data.Contradictions {
+		a, err := c.parseMemoryBytes(ct.A)
+		if err != nil {
+			return nil, err
+		}
+		b, err := c.parseMemoryBytes(ct.B)
+		if err != nil {
+			return nil, err
+		}
+		contradictions[i] = Contradiction{
+			A:          *a,
+			B:          *b,
+			Confidence: ct.Confidence,
+			Reason:     ct.Reason,
+		}
+	}
+
+	return contradictions, nil
+}
@@ -0,0 +1,80 @@
+package bravozero
+
+// MemoryDefaults are the values a MemoryClient fills in for request fields
+// the caller left unset. Nil or empty fields keep the built-in default.
+type MemoryDefaults struct {
+	// MemoryType for Record (built-in: semantic).
+	MemoryType MemoryType
+	// Importance for Record (built-in: 0.5).
+	Importance *float64
+	// Namespace for Record (built-in: the client's agent ID).
+	Namespace string
+	// QueryLimit for Query (built-in: 10).
+	QueryLimit *int
+	// MinRelevance for Query (built-in: 0.5).
+	MinRelevance *float64
+}
+
+func builtinMemoryDefaults() MemoryDefaults {
+	return MemoryDefaults{
+		MemoryType:   MemoryTypeSemantic,
+		Importance:   Float(0.5),
+		QueryLimit:   Int(10),
+		MinRelevance: Float(0.5),
+	}
+}
+
+// WithMemoryDefaults overrides the values used for unset Record and Query
+// fields. At the Client level, pass it to WithMemoryOptions.
+func WithMemoryDefaults(d MemoryDefaults) MemoryOption {
+	return func(c *MemoryClient) {
+		if d.MemoryType != "" {
+			c.defaults.MemoryType = d.MemoryType
+		}
+		if d.Importance != nil {
+			c.defaults.Importance = d.Importance
+		}
+		if d.Namespace != "" {
+			c.defaults.Namespace = d.Namespace
+		}
+		if d.QueryLimit != nil {
+			c.defaults.QueryLimit = d.QueryLimit
+		}
+		if d.MinRelevance != nil {
+			c.defaults.MinRelevance = d.MinRelevance
+		}
+	}
+}
+
+// Defaults returns the values the client uses for unset request fields.
+func (c *MemoryClient) Defaults() MemoryDefaults {
+	return c.defaults
+}
+
+func (c *MemoryClient) applyRecordDefaults(req *RecordRequest) {
+	if req.MemoryType == "" {
+		req.MemoryType = c.defaults.MemoryType
+	}
+	if req.Importance == nil {
+		req.Importance = c.defaults.Importance
+	}
+	if req.Namespace == "" {
+		req.Namespace = c.namespace
+	}
+	if req.Namespace == "" {
+		req.Namespace = c.defaults.Namespace
+	}
+	if req.Namespace == "" {
+		req.Namespace = c.agentID
+	}
+}
+
+func (c *MemoryClient) applyQueryDefaults(req *QueryRequest) {
+	if req.Limit == nil {
+		req.Limit = c.defaults.QueryLimit
+	}
+	if req.MinRelevance == nil {
+		req.MinRelevance = c.defaults.MinRelevance
+	}
+	req.Namespace = c.scopedNamespace(req.Namespace)
+}
@@ -0,0 +1,23 @@
+package bravozero
+
+// WithNamespace returns a client that shares c's configuration and
+// connection but defaults Record, Query, Count, and namespace-scoped calls
+// to ns. Explicit namespaces in requests still take precedence.
+func (c *MemoryClient) WithNamespace(ns string) *MemoryClient {
+	derived := *c
+	derived.namespace = ns
+	return &derived
+}
+
+// Namespace returns the namespace the client is scoped to, or "" if unscoped.
+func (c *MemoryClient) Namespace() string {
+	return c.namespace
+}
+
+// scopedNamespace returns ns, falling back to the client's namespace.
+func (c *MemoryClient) scopedNamespace(ns string) string {
+	if ns == "" {
+		return c.namespace
+	}
+	return ns
+}
@@ -0,0 +1,69 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// PreparedRetrieval is a named query whose results the server precomputes
+// and keeps fresh, so they can be fetched with minimal latency.
+type PreparedRetrieval struct {
+	Name        string       `json:"name"`
+	Query       QueryRequest `json:"query"`
+	RefreshedAt time.Time    `json:"refreshedAt,omitempty"`
+}
+
+// PrepareRetrieval registers (or replaces) a named precomputed retrieval.
+// Use it for the fixed context queries an agent runs at every step, then
+// read the results with GetPrepared.
+func (c *MemoryClient) PrepareRetrieval(ctx context.Context, name string, req QueryRequest) (*PreparedRetrieval, error) {
+	c.applyQueryDefaults(&req)
+
+	resp, err := c.doRequest(ctx, "PUT", "/prepared/"+url.PathEscape(name), req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Name        string       `json:"name"`
+		Query       QueryRequest `json:"query"`
+		RefreshedAt string       `json:"refreshedAt"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	refreshedAt, _ := time.Parse(time.RFC3339, data.RefreshedAt)
+
+	return &PreparedRetrieval{
+		Name:        data.Name,
+		Query:       data.Query,
+		RefreshedAt: refreshedAt,
+	}, nil
+}
+
+// GetPrepared returns the current results of a prepared retrieval.
+func (c *MemoryClient) GetPrepared(ctx context.Context, name string) ([]MemoryQueryResult, error) {
+	resp, err := c.doRequest(ctx, "GET", "/prepared/"+url.PathEscape(name)+"/results", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return c.decodeQueryResults(resp.Body)
+}
+
+// DeletePrepared removes a prepared retrieval.
+func (c *MemoryClient) DeletePrepared(ctx context.Context, name string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/prepared/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
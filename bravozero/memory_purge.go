@@ -0,0 +1,65 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PurgeReport records what PurgeBySubject deleted.
+type PurgeReport struct {
+	SubjectID        string    `json:"subjectId"`
+	DeletedMemoryIDs []string  `json:"deletedMemoryIds"`
+	DeletedEdges     int       `json:"deletedEdges"`
+	Namespaces       []string  `json:"namespaces"`
+	CompletedAt      time.Time `json:"completedAt"`
+}
+
+// PurgeBySubject permanently deletes every memory, and every edge touching
+// one, whose metadata references subjectID, across all namespaces the agent
+// can write to. Use it to honour right-to-erasure requests; the report can be
+// kept as evidence of the deletion.
+func (c *MemoryClient) PurgeBySubject(ctx context.Context, subjectID string) (*PurgeReport, error) {
+	if subjectID == "" {
+		return nil, fmt.Errorf("subject ID is required")
+	}
+	ctx, err := c.guard.check(ctx, "memory.purge", map[string]interface{}{"subjectId": subjectID})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]string{"subjectId": subjectID}
+
+	resp, err := c.doRequest(ctx, "POST", "/purge/subject", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		SubjectID        string   `json:"subjectId"`
+		DeletedMemoryIDs []string `json:"deletedMemoryIds"`
+		DeletedEdges     int      `json:"deletedEdges"`
+		Namespaces       []string `json:"namespaces"`
+		CompletedAt      string   `json:"completedAt"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, id := range data.DeletedMemoryIDs {
+		c.cache.remove(id)
+	}
+
+	completedAt, _ := time.Parse(time.RFC3339, data.CompletedAt)
+
+	return &PurgeReport{
+		SubjectID:        data.SubjectID,
+		DeletedMemoryIDs: data.DeletedMemoryIDs,
+		DeletedEdges:     data.DeletedEdges,
+		Namespaces:       data.Namespaces,
+		CompletedAt:      completedAt,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// RetentionPolicy is a server-side rule that automatically purges memories
+// in a namespace. All set criteria must match for a memory to be purged.
+type RetentionPolicy struct {
+	// Name identifies the policy within its namespace.
+	Name string `json:"name"`
+	// MaxAgeSeconds purges memories older than this.
+	MaxAgeSeconds int `json:"maxAgeSeconds,omitempty"`
+	// MemoryTypes limits the policy to these types.
+	MemoryTypes []MemoryType `json:"memoryTypes,omitempty"`
+	// Tags limits the policy to memories carrying all of these tags.
+	Tags []string `json:"tags,omitempty"`
+	// MaxStrength limits the policy to memories at or below this strength.
+	MaxStrength *float64 `json:"maxStrength,omitempty"`
+	// States limits the policy to memories in these consolidation states.
+	States []ConsolidationState `json:"states,omitempty"`
+	// Namespace and UpdatedAt are set by the server.
+	Namespace string    `json:"namespace,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// rawRetentionPolicy is the wire representation of RetentionPolicy.
+type rawRetentionPolicy struct {
+	Name          string               `json:"name"`
+	MaxAgeSeconds int                  `json:"maxAgeSeconds"`
+	MemoryTypes   []MemoryType         `json:"memoryTypes"`
+	Tags          []string             `json:"tags"`
+	MaxStrength   *float64             `json:"maxStrength"`
+	States        []ConsolidationState `json:"states"`
+	Namespace     string               `json:"namespace"`
+	UpdatedAt     string               `json:"updatedAt"`
+}
+
+func (p rawRetentionPolicy) toRetentionPolicy() *RetentionPolicy {
+	updatedAt, _ := time.Parse(time.RFC3339, p.UpdatedAt)
+
+	return &RetentionPolicy{
+		Name:          p.Name,
+		MaxAgeSeconds: p.MaxAgeSeconds,
+		MemoryTypes:   p.MemoryTypes,
+		Tags:          p.Tags,
+		MaxStrength:   p.MaxStrength,
+		States:        p.States,
+		Namespace:     p.Namespace,
+		UpdatedAt:     updatedAt,
+	}
+}
+
+func (c *MemoryClient) retentionPath(namespace string) string {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
+	return "/namespaces/" + url.PathEscape(namespace) + "/retention-policies"
+}
+
+// SetRetentionPolicy creates or replaces the named retention policy on namespace.
+func (c *MemoryClient) SetRetentionPolicy(ctx context.Context, namespace string, policy RetentionPolicy) (*RetentionPolicy, error) {
+	if policy.Name == "" {
+		return nil, fmt.Errorf("retention policy name required")
+	}
+
+	ctx, err := c.guard.check(ctx, "memory.set_retention_policy", map[string]interface{}{"namespace": namespace, "name": policy.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", c.retentionPath(namespace)+"/"+url.PathEscape(policy.Name), policy)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawRetentionPolicy
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.toRetentionPolicy(), nil
+}
+
+// ListRetentionPolicies lists the retention policies on namespace.
+func (c *MemoryClient) ListRetentionPolicies(ctx context.Context, namespace string) ([]RetentionPolicy, error) {
+	resp, err := c.doRequest(ctx, "GET", c.retentionPath(namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Policies []rawRetentionPolicy `json:"policies"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	policies := make([]RetentionPolicy, len(data.Policies))
+	for i, p := range data.Policies {
+		policies[i] = *p.toRetentionPolicy()
+	}
+
+	return policies, nil
+}
+
+// GetRetentionPolicy retrieves a retention policy by name.
+func (c *MemoryClient) GetRetentionPolicy(ctx context.Context, namespace, name string) (*RetentionPolicy, error) {
+	resp, err := c.doRequest(ctx, "GET", c.retentionPath(namespace)+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data rawRetentionPolicy
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return data.toRetentionPolicy(), nil
+}
+
+// DeleteRetentionPolicy removes a retention policy.
+func (c *MemoryClient) DeleteRetentionPolicy(ctx context.Context, namespace, name string) error {
+	ctx, err := c.guard.check(ctx, "memory.delete_retention_policy", map[string]interface{}{"namespace": namespace, "name": name})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, "DELETE", c.retentionPath(namespace)+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,124 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// NamespaceAccess is the level of access granted on a namespace.
+type NamespaceAccess string
+
+const (
+	NamespaceRead      NamespaceAccess = "read"
+	NamespaceReadWrite NamespaceAccess = "read_write"
+)
+
+// NamespaceGrant gives another agent access to a namespace.
+type NamespaceGrant struct {
+	Namespace string          `json:"namespace"`
+	AgentID   string          `json:"agentId"`
+	Access    NamespaceAccess `json:"access"`
+	GrantedBy string          `json:"grantedBy"`
+	GrantedAt time.Time       `json:"grantedAt"`
+}
+
+func (c *MemoryClient) grantsPath(namespace string) string {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
+	return "/namespaces/" + url.PathEscape(namespace) + "/grants"
+}
+
+// ShareNamespace grants agentID access to namespace, replacing any existing
+// grant for that agent.
+func (c *MemoryClient) ShareNamespace(ctx context.Context, namespace, agentID string, access NamespaceAccess) (*NamespaceGrant, error) {
+	ctx, err := c.guard.check(ctx, "memory.share_namespace", map[string]interface{}{"namespace": namespace, "agentId": agentID, "access": access})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"agentId": agentID,
+		"access":  access,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", c.grantsPath(namespace), body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseNamespaceGrant(resp.Body)
+}
+
+// ListGrants lists the agents with access to namespace.
+func (c *MemoryClient) ListGrants(ctx context.Context, namespace string) ([]NamespaceGrant, error) {
+	resp, err := c.doRequest(ctx, "GET", c.grantsPath(namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Grants []rawNamespaceGrant `json:"grants"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	grants := make([]NamespaceGrant, len(data.Grants))
+	for i, g := range data.Grants {
+		grants[i] = g.toGrant()
+	}
+
+	return grants, nil
+}
+
+// Revoke removes agentID's access to namespace.
+func (c *MemoryClient) Revoke(ctx context.Context, namespace, agentID string) error {
+	ctx, err := c.guard.check(ctx, "memory.revoke", map[string]interface{}{"namespace": namespace, "agentId": agentID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, "DELETE", c.grantsPath(namespace)+"/"+url.PathEscape(agentID), nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+type rawNamespaceGrant struct {
+	Namespace string `json:"namespace"`
+	AgentID   string `json:"agentId"`
+	Access    string `json:"access"`
+	GrantedBy string `json:"grantedBy"`
+	GrantedAt string `json:"grantedAt"`
+}
+
+func (g rawNamespaceGrant) toGrant() NamespaceGrant {
+	grantedAt, _ := time.Parse(time.RFC3339, g.GrantedAt)
+	return NamespaceGrant{
+		Namespace: g.Namespace,
+		AgentID:   g.AgentID,
+		Access:    NamespaceAccess(g.Access),
+		GrantedBy: g.GrantedBy,
+		GrantedAt: grantedAt,
+	}
+}
+
+func parseNamespaceGrant(r io.Reader) (*NamespaceGrant, error) {
+	var data rawNamespaceGrant
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	grant := data.toGrant()
+	return &grant, nil
+}
@@ -0,0 +1,112 @@
+package bravozero
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// NamespaceSnapshot is a point-in-time capture of a namespace's memories and edges.
+type NamespaceSnapshot struct {
+	ID          string    `json:"id"`
+	Namespace   string    `json:"namespace"`
+	MemoryCount int       `json:"memoryCount"`
+	EdgeCount   int       `json:"edgeCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Snapshot captures the current state of namespace so it can later be
+// restored with RestoreSnapshot.
+func (c *MemoryClient) Snapshot(ctx context.Context, namespace string) (*NamespaceSnapshot, error) {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
+
+	body := map[string]string{"namespace": namespace}
+
+	resp, err := c.doRequest(ctx, "POST", "/snapshots", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return parseSnapshot(resp.Body)
+}
+
+// ListSnapshots lists the snapshots of namespace, newest first.
+func (c *MemoryClient) ListSnapshots(ctx context.Context, namespace string) ([]NamespaceSnapshot, error) {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/snapshots?namespace="+url.QueryEscape(namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Snapshots []json.RawMessage `json:"snapshots"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	snapshots := make([]NamespaceSnapshot, len(data.Snapshots))
+	for i, raw := range data.Snapshots {
+		snapshot, err := parseSnapshot(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i] = *snapshot
+	}
+
+	return snapshots, nil
+}
+
+// RestoreSnapshot rolls the snapshot's namespace back to the captured state,
+// discarding memories and edges created since.
+func (c *MemoryClient) RestoreSnapshot(ctx context.Context, snapshotID string) error {
+	ctx, err := c.guard.check(ctx, "memory.restore_snapshot", map[string]interface{}{"snapshotId": snapshotID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/snapshots/"+url.PathEscape(snapshotID)+"/restore", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func parseSnapshot(r io.Reader) (*NamespaceSnapshot, error) {
+	var data struct {
+		ID          string `json:"id"`
+		Namespace   string `json:"namespace"`
+		MemoryCount int    `json:"memoryCount"`
+		EdgeCount   int    `json:"edgeCount"`
+		CreatedAt   string `json:"createdAt"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, data.CreatedAt)
+
+	return &NamespaceSnapshot{
+		ID:          data.ID,
+		Namespace:   data.Namespace,
+		MemoryCount: data.MemoryCount,
+		EdgeCount:   data.EdgeCount,
+		CreatedAt:   createdAt,
+	}, nil
+}
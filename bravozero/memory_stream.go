@@ -0,0 +1,87 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// QueryEach runs a query and calls fn for each result as it is decoded from
+// the response, without buffering the whole result set. Use it for large
+// Limit values. If fn returns an error, decoding stops and the error is returned.
+func (c *MemoryClient) QueryEach(ctx context.Context, req QueryRequest, fn func(MemoryQueryResult) error) error {
+	c.applyQueryDefaults(&req)
+
+	resp, err := c.doRequest(ctx, "POST", "/query", req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return c.streamQueryResults(resp.Body, fn)
+}
+
+// streamQueryResults decodes a {"results": [...]} body one element at a time.
+func (c *MemoryClient) streamQueryResults(r io.Reader, fn func(MemoryQueryResult) error) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		key, _ := tok.(string)
+		if key != "results" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			var item struct {
+				Memory      json.RawMessage   `json:"memory"`
+				Relevance   float64           `json:"relevance"`
+				Explanation *ScoreExplanation `json:"explanation"`
+			}
+			if err := dec.Decode(&item); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			memory, err := c.parseMemoryBytes(item.Memory)
+			if err != nil {
+				return err
+			}
+			result := MemoryQueryResult{
+				Memory:      *memory,
+				Relevance:   item.Relevance,
+				Explanation: item.Explanation,
+			}
+			if err := fn(result); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("failed to decode response: expected %q, got %v", want, tok)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// TimelineEntry is an episodic memory in a Timeline together with its
+// outgoing follows edges, which point at the memories it came after.
+type TimelineEntry struct {
+	Memory  Memory `json:"memory"`
+	Follows []Edge `json:"follows"`
+}
+
+// Timeline returns the episodic memories in namespace created between from
+// and to, oldest first. A zero from or to leaves that end of the range open.
+func (c *MemoryClient) Timeline(ctx context.Context, namespace string, from, to time.Time) ([]TimelineEntry, error) {
+	namespace = c.scopedNamespace(namespace)
+	if namespace == "" {
+		namespace = c.agentID
+	}
+
+	params := url.Values{}
+	params.Set("namespace", namespace)
+	if !from.IsZero() {
+		params.Set("from", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		params.Set("to", to.Format(time.RFC3339))
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/timeline?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Entries []struct {
+			Memory  json.RawMessage `json:"memory"`
+			Follows []rawEdge       `json:"follows"`
+		} `json:"entries"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	entries := make([]TimelineEntry, len(data.Entries))
+	for i, e := range data.Entries {
+		memory, err := c.parseMemoryBytes(e.Memory)
+		if err != nil {
+			return nil, err
+		}
+		entries[i].Memory = *memory
+		entries[i].Follows = make([]Edge, len(e.Follows))
+		for j, edge := range e.Follows {
+			entries[i].Follows[j] = *edge.toEdge()
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Memory.CreatedAt.Before(entries[j].Memory.CreatedAt)
+	})
+
+	return entries, nil
+}
@@ -0,0 +1,172 @@
+package bravozero
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// GetMetadata returns the metadata value for key converted to T. It reports
+// false if the key is missing or cannot be converted. JSON numbers convert
+// to any numeric type, RFC 3339 strings to time.Time, and objects to structs.
+// Numbers only convert to an integer type if they are whole and in range for
+// it; fractions are never truncated.
+func GetMetadata[T any](m *Memory, key string) (T, bool) {
+	var zero T
+	if m == nil {
+		return zero, false
+	}
+	return metadataValue[T](m.Metadata, key)
+}
+
+func metadataValue[T any](metadata map[string]interface{}, key string) (T, bool) {
+	var zero T
+	raw, ok := metadata[key]
+	if !ok || raw == nil {
+		return zero, false
+	}
+	if v, ok := raw.(T); ok {
+		return v, true
+	}
+
+	switch any(zero).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		f, ok := raw.(float64)
+		if !ok {
+			return zero, false
+		}
+		return convertNumber[T](f)
+	case time.Time:
+		s, ok := raw.(string)
+		if !ok {
+			return zero, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return zero, false
+		}
+		return any(t).(T), true
+	}
+
+	// Fall back to a JSON round trip for slices, maps, and structs.
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return zero, false
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return zero, false
+	}
+	return v, true
+}
+
+func convertNumber[T any](f float64) (T, bool) {
+	var zero T
+	if _, ok := any(zero).(float32); ok {
+		return any(float32(f)).(T), true
+	}
+
+	// Integer conversions must round-trip exactly, which rejects fractions,
+	// negative values for unsigned types, and out-of-range values.
+	var v interface{}
+	var back float64
+	switch any(zero).(type) {
+	case int:
+		n := int(f)
+		v, back = n, float64(n)
+	case int8:
+		n := int8(f)
+		v, back = n, float64(n)
+	case int16:
+		n := int16(f)
+		v, back = n, float64(n)
+	case int32:
+		n := int32(f)
+		v, back = n, float64(n)
+	case int64:
+		n := int64(f)
+		v, back = n, float64(n)
+	case uint:
+		n := uint(f)
+		v, back = n, float64(n)
+	case uint8:
+		n := uint8(f)
+		v, back = n, float64(n)
+	case uint16:
+		n := uint16(f)
+		v, back = n, float64(n)
+	case uint32:
+		n := uint32(f)
+		v, back = n, float64(n)
+	case uint64:
+		n := uint64(f)
+		v, back = n, float64(n)
+	default:
+		return zero, false
+	}
+	if back != f {
+		return zero, false
+	}
+	return v.(T), true
+}
+
+// MetadataBuilder builds a metadata map with typed setters.
+//
+//	req.Metadata = bravozero.NewMetadata().
+//		String("source", "slack").
+//		Int("turn", 3).
+//		Build()
+type MetadataBuilder struct {
+	m map[string]interface{}
+}
+
+// NewMetadata returns an empty MetadataBuilder.
+func NewMetadata() *MetadataBuilder {
+	return &MetadataBuilder{m: make(map[string]interface{})}
+}
+
+// String sets a string value.
+func (b *MetadataBuilder) String(key, value string) *MetadataBuilder {
+	b.m[key] = value
+	return b
+}
+
+// Int sets an integer value.
+func (b *MetadataBuilder) Int(key string, value int64) *MetadataBuilder {
+	b.m[key] = value
+	return b
+}
+
+// Float sets a floating-point value.
+func (b *MetadataBuilder) Float(key string, value float64) *MetadataBuilder {
+	b.m[key] = value
+	return b
+}
+
+// Bool sets a boolean value.
+func (b *MetadataBuilder) Bool(key string, value bool) *MetadataBuilder {
+	b.m[key] = value
+	return b
+}
+
+// Time sets a timestamp, stored as an RFC 3339 string.
+func (b *MetadataBuilder) Time(key string, value time.Time) *MetadataBuilder {
+	b.m[key] = value.Format(time.RFC3339Nano)
+	return b
+}
+
+// Strings sets a list of strings.
+func (b *MetadataBuilder) Strings(key string, values ...string) *MetadataBuilder {
+	b.m[key] = values
+	return b
+}
+
+// Any sets an arbitrary JSON-serializable value.
+func (b *MetadataBuilder) Any(key string, value interface{}) *MetadataBuilder {
+	b.m[key] = value
+	return b
+}
+
+// Build returns the metadata map.
+func (b *MetadataBuilder) Build() map[string]interface{} {
+	return b.m
+}
@@ -0,0 +1,134 @@
+package bravozero
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Provenance records where a memory came from.
+type Provenance struct {
+	// SourceSystem is the system that produced the memory, e.g. "slack" or "ci".
+	SourceSystem string `json:"sourceSystem,omitempty"`
+	// Tool is the tool invocation that produced the memory.
+	Tool string `json:"tool,omitempty"`
+	// TaskID identifies the task the agent was working on.
+	TaskID string `json:"taskId,omitempty"`
+	// Model is the model that generated the content, if any.
+	Model string `json:"model,omitempty"`
+	// Confidence is the producer's confidence in the content, from 0 to 1.
+	Confidence float64 `json:"confidence,omitempty"`
+	// OriginAgentID is the agent that first produced the information.
+	OriginAgentID string `json:"originAgentId,omitempty"`
+	// DocumentURI points at the source document, if any.
+	DocumentURI string `json:"documentUri,omitempty"`
+	// ParentMemoryID is the memory this one was derived from. The server
+	// links the two with a RelDerivedFrom edge.
+	ParentMemoryID string `json:"parentMemoryId,omitempty"`
+}
+
+// GetSourceSystem returns the source system, or "" if p is nil.
+func (p *Provenance) GetSourceSystem() string {
+	if p == nil {
+		return ""
+	}
+	return p.SourceSystem
+}
+
+// GetTool returns the tool, or "" if p is nil.
+func (p *Provenance) GetTool() string {
+	if p == nil {
+		return ""
+	}
+	return p.Tool
+}
+
+// GetTaskID returns the task ID, or "" if p is nil.
+func (p *Provenance) GetTaskID() string {
+	if p == nil {
+		return ""
+	}
+	return p.TaskID
+}
+
+// GetModel returns the model, or "" if p is nil.
+func (p *Provenance) GetModel() string {
+	if p == nil {
+		return ""
+	}
+	return p.Model
+}
+
+// GetConfidence returns the confidence, or 0 if p is nil.
+func (p *Provenance) GetConfidence() float64 {
+	if p == nil {
+		return 0
+	}
+	return p.Confidence
+}
+
+// GetOriginAgentID returns the origin agent ID, or "" if p is nil.
+func (p *Provenance) GetOriginAgentID() string {
+	if p == nil {
+		return ""
+	}
+	return p.OriginAgentID
+}
+
+// GetDocumentURI returns the document URI, or "" if p is nil.
+func (p *Provenance) GetDocumentURI() string {
+	if p == nil {
+		return ""
+	}
+	return p.DocumentURI
+}
+
+// GetParentMemoryID returns the parent memory ID, or "" if p is nil.
+func (p *Provenance) GetParentMemoryID() string {
+	if p == nil {
+		return ""
+	}
+	return p.ParentMemoryID
+}
+
+// ProvenanceFilter restricts queries to memories with matching provenance.
+// Empty fields are ignored.
+type ProvenanceFilter struct {
+	SourceSystem  string  `json:"sourceSystem,omitempty"`
+	Tool          string  `json:"tool,omitempty"`
+	TaskID        string  `json:"taskId,omitempty"`
+	Model         string  `json:"model,omitempty"`
+	MinConfidence float64 `json:"minConfidence,omitempty"`
+	OriginAgentID string  `json:"originAgentId,omitempty"`
+	DocumentURI   string  `json:"documentUri,omitempty"`
+}
+
+// GetProvenance walks derivation edges from a memory back to its original
+// source. The returned chain starts with the memory itself and ends with the
+// root memory that has no parent.
+func (c *MemoryClient) GetProvenance(ctx context.Context, memoryID string) ([]Memory, error) {
+	resp, err := c.doRequest(ctx, "GET", "/"+memoryID+"/provenance", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Chain []json.RawMessage `json:"chain"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	chain := make([]Memory, len(data.Chain))
+	for i, raw := range data.Chain {
+		memory, err := c.parseMemoryBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		chain[i] = *memory
+	}
+
+	return chain, nil
+}
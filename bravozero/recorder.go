@@ -0,0 +1,290 @@
+package bravozero
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrRecorderClosed is returned when recording to a closed AsyncRecorder.
+var ErrRecorderClosed = errors.New("async recorder is closed")
+
+// ErrRecorderFull is returned when the AsyncRecorder queue is at capacity.
+var ErrRecorderFull = errors.New("async recorder queue is full")
+
+type recorderConfig struct {
+	batchSize     int
+	flushInterval time.Duration
+	queueSize     int
+	maxRetries    int
+	retryBackoff  time.Duration
+	onError       func(error, []RecordRequest)
+}
+
+// RecorderOption configures an AsyncRecorder.
+type RecorderOption func(*recorderConfig)
+
+// WithBatchSize sets how many memories are sent per batch (default 100).
+func WithBatchSize(n int) RecorderOption {
+	return func(c *recorderConfig) {
+		c.batchSize = n
+	}
+}
+
+// WithFlushInterval sets the maximum time a memory waits in the queue
+// before being sent (default 1s).
+func WithFlushInterval(d time.Duration) RecorderOption {
+	return func(c *recorderConfig) {
+		c.flushInterval = d
+	}
+}
+
+// WithQueueSize sets the maximum number of queued memories (default 10000).
+func WithQueueSize(n int) RecorderOption {
+	return func(c *recorderConfig) {
+		c.queueSize = n
+	}
+}
+
+// WithRecorderRetries sets how many times a failed batch is retried and the
+// initial backoff, which doubles after each attempt (default 3, 200ms). Only
+// transport errors, rate limits and 5xx responses are retried. Every attempt
+// at a batch carries the same idempotency key, so a batch the service stored
+// before the connection dropped is not recorded twice.
+func WithRecorderRetries(maxRetries int, backoff time.Duration) RecorderOption {
+	return func(c *recorderConfig) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithRecorderErrorHandler sets a callback for batches that could not be
+// recorded after all retries.
+func WithRecorderErrorHandler(fn func(err error, batch []RecordRequest)) RecorderOption {
+	return func(c *recorderConfig) {
+		c.onError = fn
+	}
+}
+
+// AsyncRecorder queues memories and records them in the background in
+// batches, keeping HTTP calls off the caller's hot path.
+type AsyncRecorder struct {
+	client  *MemoryClient
+	cfg     recorderConfig
+	queue   chan RecordRequest
+	flushCh chan chan error
+	done    chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+	// closeErr is the error of the final flush, set before done is closed.
+	closeErr error
+}
+
+// NewAsyncRecorder creates and starts an AsyncRecorder. Call Close to flush
+// remaining memories and stop the background goroutine.
+func NewAsyncRecorder(client *MemoryClient, opts ...RecorderOption) *AsyncRecorder {
+	cfg := recorderConfig{
+		batchSize:     100,
+		flushInterval: time.Second,
+		queueSize:     10000,
+		maxRetries:    3,
+		retryBackoff:  200 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.batchSize <= 0 {
+		cfg.batchSize = 1
+	}
+	if cfg.flushInterval <= 0 {
+		cfg.flushInterval = time.Second
+	}
+
+	r := &AsyncRecorder{
+		client:  client,
+		cfg:     cfg,
+		queue:   make(chan RecordRequest, cfg.queueSize),
+		flushCh: make(chan chan error),
+		done:    make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record queues a memory for recording. It never blocks; it returns
+// ErrRecorderFull if the queue is at capacity.
+func (r *AsyncRecorder) Record(req RecordRequest) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return ErrRecorderClosed
+	}
+	select {
+	case r.queue <- req:
+		return nil
+	default:
+		return ErrRecorderFull
+	}
+}
+
+// Flush sends all queued memories and waits until they are recorded or ctx
+// is done. It returns the last send error, if any.
+func (r *AsyncRecorder) Flush(ctx context.Context) error {
+	reply := make(chan error, 1)
+	select {
+	case r.flushCh <- reply:
+	case <-r.done:
+		return ErrRecorderClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting memories, flushes the queue, and waits for the
+// background goroutine to exit. It returns the error of the final flush, if
+// any.
+func (r *AsyncRecorder) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		<-r.done
+		return r.closeErr
+	}
+	r.closed = true
+	close(r.queue)
+	r.mu.Unlock()
+
+	<-r.done
+	return r.closeErr
+}
+
+func (r *AsyncRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]RecordRequest, 0, r.cfg.batchSize)
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := r.send(batch)
+		batch = make([]RecordRequest, 0, r.cfg.batchSize)
+		return err
+	}
+
+	for {
+		select {
+		case req, ok := <-r.queue:
+			if !ok {
+				r.closeErr = send()
+				return
+			}
+			batch = append(batch, req)
+			if len(batch) >= r.cfg.batchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case reply := <-r.flushCh:
+			var err error
+			// Drain whatever is queued at the time of the flush request.
+			for n := len(r.queue); n > 0; n-- {
+				req, ok := <-r.queue
+				if !ok {
+					break
+				}
+				batch = append(batch, req)
+				if len(batch) >= r.cfg.batchSize {
+					if sendErr := send(); sendErr != nil {
+						err = sendErr
+					}
+				}
+			}
+			if sendErr := send(); sendErr != nil {
+				err = sendErr
+			}
+			reply <- err
+		}
+	}
+}
+
+func (r *AsyncRecorder) send(batch []RecordRequest) error {
+	timeout := r.client.httpClient.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		if r.cfg.onError != nil {
+			r.cfg.onError(err, batch)
+		}
+		return err
+	}
+
+	backoff := r.cfg.retryBackoff
+	for attempt := 0; attempt <= r.cfg.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		_, err = r.client.recordBatch(ctx, batch, key)
+		cancel()
+		if err == nil || !retryableRecordError(err) {
+			break
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	if r.cfg.onError != nil {
+		r.cfg.onError(err, batch)
+	}
+	return err
+}
+
+// newIdempotencyKey returns a random key identifying one batch across its
+// retries.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// retryableRecordError reports whether a failed batch may succeed if sent
+// again. Other 4xx responses, guard denials and decode errors are permanent.
+func retryableRecordError(err error) bool {
+	var httpErr *HTTPError
+	var rateLimit *RateLimitError
+	var urlErr *url.Error
+	var keyExpired *KeyExpiredError
+
+	switch {
+	case errors.As(err, &keyExpired):
+		return false
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode >= 500
+	case errors.As(err, &rateLimit), errors.As(err, &urlErr):
+		return true
+	default:
+		return false
+	}
+}
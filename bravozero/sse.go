@@ -0,0 +1,110 @@
+package bravozero
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sseEvent is a single server-sent event.
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readSSE parses a text/event-stream body and calls fn for each event until
+// the stream ends or fn returns an error.
+func readSSE(r io.Reader, fn func(sseEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var ev sseEvent
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				ev.Data = strings.Join(data, "\n")
+				if err := fn(ev); err != nil {
+					return err
+				}
+			}
+			ev = sseEvent{}
+			data = data[:0]
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			ev.ID = value
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
+
+// newSSERequest builds the GET request for a server-sent event stream,
+// resuming after lastEventID if it is set.
+func newSSERequest(ctx context.Context, url, apiKey, agentID string, auth *PersonaAuthenticator, lastEventID string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-API-Key", apiKey)
+	req.Header.Set("X-Agent-ID", agentID)
+	req.Header.Set("User-Agent", "bravozero-go/"+Version)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	if auth != nil {
+		attestation, err := auth.CreateAttestation("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create attestation: %w", err)
+		}
+		req.Header.Set("X-Persona-Attestation", attestation)
+	}
+
+	if token := executionTokenFromContext(ctx); token != "" {
+		req.Header.Set("X-Execution-Token", token)
+	}
+
+	return req, nil
+}
+
+// openSSE sends a request built by newSSERequest. client must not have a
+// request timeout, since the stream is long-lived. retryable reports whether
+// a failure, or the stream later ending, is worth reconnecting after. Error
+// responses are returned as *HTTPError or *RateLimitError.
+func openSSE(client *http.Client, req *http.Request) (resp *http.Response, retryable bool, err error) {
+	resp, err = client.Do(req)
+	if err != nil {
+		return nil, true, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode == 429 {
+		resp.Body.Close()
+		return nil, true, &RateLimitError{RetryAfter: 60}
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, resp.StatusCode >= 500, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return resp, true, nil
+}
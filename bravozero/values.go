@@ -0,0 +1,30 @@
+package bravozero
+
+// Optional request fields are pointers so that an explicit zero value can be
+// distinguished from "unset, use the default". These helpers build them
+// inline:
+//
+//	client.Memory().Query(ctx, bravozero.QueryRequest{
+//		Query:        "deploy steps",
+//		MinRelevance: bravozero.Float(0),
+//	})
+
+// Float returns a pointer to v, for optional request fields where an
+// explicit zero must be distinguishable from unset.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Int returns a pointer to v, for optional request fields where an
+// explicit zero must be distinguishable from unset.
+func Int(v int) *int {
+	return &v
+}
+
+// ValueOr returns *p, or def if p is nil.
+func ValueOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
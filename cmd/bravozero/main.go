@@ -0,0 +1,73 @@
+// Command bravozero is a command-line tool for the Bravo Zero platform.
+//
+// Usage:
+//
+//	bravozero doctor [-json]
+//
+// Credentials are read from BRAVOZERO_API_KEY, BRAVOZERO_AGENT_ID, and
+// BRAVOZERO_PRIVATE_KEY_PATH.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/DeepCreative/bravozero-go/bravozero"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "doctor":
+		os.Exit(doctor(os.Args[2:]))
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: bravozero doctor [-json]")
+}
+
+func doctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	timeout := fs.Duration("timeout", 30*time.Second, "overall timeout")
+	fs.Parse(args)
+
+	client, err := bravozero.NewClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report := client.Doctor(ctx)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		for _, check := range report.Checks {
+			fmt.Printf("%-4s  %-24s %s\n", check.Status, check.Name, check.Message)
+		}
+	}
+
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}